@@ -20,23 +20,53 @@ import (
 	"bytes"
 	"errors"
 	"os"
-	"syscall"
+	"strings"
 	"unicode/utf8"
 )
 
 // ErrInvalidDrive indicates an invalid character was used as a drive letter.
 var ErrInvalidDrive = errors.New("path: invalid drive specified")
 
+// ErrReservedName indicates a reserved DOS device name, such as ``CON'' or
+// ``nul.txt'', was used as a file or directory name.
+var ErrReservedName = errors.New("path: a reserved device name is present")
+
 // PathImpl holds state between each of the functional calls returned by Path().
+//
+// A PathImpl is never modified once returned by Path(); every operation
+// returns a new PathImpl instead. It is therefore safe for concurrent use by
+// multiple goroutines, as long as the slices returned by Dirs() and Errors()
+// are not modified.
 type PathImpl struct {
-	node     string
-	device   string
-	name     string
-	dirs     []string
-	absolute bool
-	unc      bool
-	unicode  bool
-	errs     []error
+	node       string
+	share      string
+	device     string
+	deviceName string
+	name       string
+	dirs       []string
+	absolute   bool
+	unc        bool
+	unicode    bool
+	devicePath bool
+	ntPrefix   string
+	volume     *GUID
+	rootDevice bool
+	stream     string
+	streamType string
+	separators SeparatorStyle
+	shortName  string
+	shortOf    string
+	opts       PathOptions
+	null       bool
+	errs       []error
+}
+
+// clone returns a copy of the Path, which shares no state with the original.
+func (p *PathImpl) clone() *PathImpl {
+	c := *p
+	c.dirs = append([]string(nil), p.dirs...)
+	c.errs = append([]error(nil), p.errs...)
+	return &c
 }
 
 func isDriveLetter(c rune) (rune, error) {
@@ -46,7 +76,7 @@ func isDriveLetter(c rune) (rune, error) {
 	case c >= 'A' && c <= 'Z':
 		return c, nil
 	default:
-		return -1, errors.New("isDriveLetter: an invalid drive letter was found")
+		return -1, ErrInvalidDrive
 	}
 }
 
@@ -57,15 +87,15 @@ func isPathNameLetter(c rune) (rune, error) {
 	switch c {
 	case '<', '>', ':', '"', '/', '\\', '|', '?', '*':
 		// reserved characters
-		return -1, errors.New("isPathNameLetter: a reserved character is present")
+		return -1, ErrReservedChar
 	default:
 		switch {
 		case c == 0:
 			// cannot be the NULL character
-			return c, errors.New("isPathNameLetter: a NULL rune is present")
+			return c, ErrControlChar
 		case c >= 1 && c <= 31:
 			// invalid except for alternate data streams
-			return c, errors.New("isPathNameLetter: an invalid rune is present; unless a File Stream")
+			return c, ErrControlChar
 		default:
 			// Valid for GENERAL Windows file naming rules, although FS may impose additional restrictions
 			return c, nil
@@ -73,6 +103,31 @@ func isPathNameLetter(c rune) (rune, error) {
 	}
 }
 
+// isReservedName determines if the given file or directory name refers to a
+// reserved DOS device. Any extension, along with spaces preceding it, is
+// ignored; so ``nul.txt'' and ``con .log'' are reserved as well.
+//
+// See: https://msdn.microsoft.com/en-us/library/windows/desktop/aa365247(v=vs.85).aspx
+func isReservedName(name string) bool {
+	if i := strings.IndexByte(name, '.'); i >= 0 {
+		name = name[:i]
+	}
+	name = strings.TrimRight(name, " ")
+
+	if len(name) == 3 {
+		return strings.EqualFold(name, "CON") || strings.EqualFold(name, "PRN") ||
+			strings.EqualFold(name, "AUX") || strings.EqualFold(name, "NUL")
+	}
+	if len(name) < 4 || !(strings.EqualFold(name[:3], "COM") || strings.EqualFold(name[:3], "LPT")) {
+		return false
+	}
+	switch name[3:] {
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9", "\u00b9", "\u00b2", "\u00b3":
+		return true
+	}
+	return false
+}
+
 const (
 	stateStart int = iota
 	stateUNC
@@ -84,34 +139,75 @@ const (
 	substateStart int = iota
 	substateUnicode
 	substateUnicodeUNC
+	substateDevice
+	substateShare
 )
 
+// runeIndex returns the rune offset of the given byte index of s.
+func runeIndex(s string, i int) int {
+	return utf8.RuneCountInString(s[:i])
+}
+
+// driveLetters holds the possible drive letters, so a Device() may be
+// sliced rather than allocated.
+const driveLetters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// driveLetter returns the upper case drive letter c as a string.
+func driveLetter(c rune) string {
+	return driveLetters[c-'A' : c-'A'+1]
+}
+
 // newPathImpl parses and returns a new PathImpl from a given string.
 //
-// See Path() for more.
-func newPathImpl(path string) *PathImpl {
-	_path := &PathImpl{}
+// The parser works on byte offsets, with every component being a substring
+// of the given string; so parsing allocates little more than the PathImpl
+// and its Dirs(). Every separator and reserved character is ASCII, so only
+// ASCII bytes are validated; rune offsets are only counted for errors.
+//
+// See Path() for more; and ParseWith() for the options.
+func newPathImpl(path string, opts PathOptions) *PathImpl {
+	if !utf8.ValidString(path) {
+		// replace each invalid byte with U+FFFD, as converting to runes does
+		path = string([]rune(path))
+	}
 
-	runeArrayLen := utf8.RuneCountInString(path)
-	runeArray := make([]rune, runeArrayLen)
+	_path := &PathImpl{opts: opts}
 
-	for index, runeValue := range path {
-		runeArray[index] = runeValue
-	}
+	pathLen := len(path)
 
 	curIdx := 0
 	curState := stateStart
 	curSubState := substateStart
-	var curStack []rune
+	curStart := -1
+	compStart := -1
+	inStream, streamIdx := false, 0
+	// a pipe name may hold any character but a backslash
+	pipe := false
+	// only files may have streams; not pipes or mailslots
+	streams := true
+
+	if prefix, isDevice := ntNamespacePrefix(path, opts.NTDevices); len(prefix) > 0 {
+		// NT object manager paths are never normalized, just as UNICODE paths
+		_path.ntPrefix = prefix
+		_path.unicode = true
+		curIdx = len(prefix)
+		curState = stateUNC
+		curSubState = substateUnicode
+		if isDevice {
+			curSubState = substateDevice
+		}
+	}
 loopStart:
-	for curIdx < runeArrayLen {
+	for curIdx < pathLen {
+		c := path[curIdx]
+
 		switch curState {
 		case stateStart:
-			if runeArray[curIdx] == '\\' && curIdx+1 < runeArrayLen && runeArray[curIdx+1] == '\\' {
+			if _path.isSeparator(rune(c)) && curIdx+1 < pathLen && _path.isSeparator(rune(path[curIdx+1])) {
 				// UNC path
 				curIdx++
 				curState = stateUNC
-			} else if runeArray[curIdx] == '\\' {
+			} else if _path.isSeparator(rune(c)) {
 				// Abs path
 				// BEGIN PathState
 				_path.absolute = true
@@ -122,141 +218,270 @@ loopStart:
 			}
 		case stateDrive:
 			// BEGIN DriveLetterState
-			c, err := isDriveLetter(runeArray[curIdx])
-			if err == nil {
-				// Drive letter was found, but is the next rune a colon?
-				if curIdx+1 < runeArrayLen && runeArray[curIdx+1] == ':' {
-					// for sure a drive, start path parsing state
-					_path.device = string(c)
-
-					if curIdx+2 < runeArrayLen && runeArray[curIdx+2] == '\\' {
-						_path.absolute = true
-					}
-					// else RELATIVE to current working directory, but on this OTHER drive!
+			if d, err := isDriveLetter(rune(c)); err == nil && curIdx+1 < pathLen && path[curIdx+1] == ':' {
+				// for sure a drive, start path parsing state
+				_path.device = driveLetter(d)
 
-					curIdx++
-					curIdx++
-					// was a drive, start path components
-					curState = statePathComponent
-					goto loopStart
-				} else {
-					// was a drive, start path components
-					curState = statePathComponent
-					goto loopStart
+				if curIdx+2 < pathLen && _path.isSeparator(rune(path[curIdx+2])) {
+					_path.absolute = true
 				}
+				// else RELATIVE to current working directory, but on this OTHER drive!
+
+				curIdx += 2
 			}
-			// not a drive, but maybe a relative path?
+			// was a drive, or maybe a relative path; start path components
 			curState = statePathComponent
 			goto loopStart
 		case statePathComponent:
 			// either have a path or a file name as the possibility...
-			if runeArray[curIdx] == '\\' {
-				if len(curStack) > 0 {
+			switch {
+			case _path.isSeparator(rune(c)):
+				if inStream {
+					// only the final component may name a stream
+					_path.addError(ErrReservedChar, runeIndex(path, streamIdx), len(_path.dirs), ':')
+					inStream = false
+				}
+				if curStart >= 0 {
 					// finished a component of the path, push and continue
-					_path.dirs = append(_path.dirs, string(curStack))
-					curStack = make([]rune, 0, 160)
+					if _path.dirs == nil {
+						compStart = curStart
+						_path.dirs = make([]string, 0, strings.Count(path[curIdx:], "\\")+strings.Count(path[curIdx:], "/"))
+					}
+					_path.dirs = append(_path.dirs, path[curStart:curIdx])
+					curStart = -1
 				}
-			} else {
-				if _, err := isPathNameLetter(runeArray[curIdx]); err != nil {
-					_path.errs = append(_path.errs, err)
+			case streams && (c == ':' || inStream):
+				// possibly a stream; validated once the final component is known
+				if !inStream {
+					inStream, streamIdx = true, curIdx
+				}
+				if curStart < 0 {
+					curStart = curIdx
+				}
+			default:
+				if curStart < 0 {
+					curStart = curIdx
+				}
+				// every byte of a multi-byte rune is a valid letter
+				if c < utf8.RuneSelf && !pipe {
+					if _, err := isPathNameLetter(rune(c)); err != nil {
+						_path.addError(err, runeIndex(path, curIdx), len(_path.dirs), rune(c))
+					}
 				}
-				curStack = append(curStack, runeArray[curIdx])
 			}
 		case stateUNC:
-			if runeArray[curIdx] == '\\' {
-				if len(curStack) > 0 {
-					// finished a component of the node
-					node := string(curStack)
-					curStack = make([]rune, 0, 160)
-
-					switch curSubState {
-					case substateUnicode:
-						if node == "UNC" {
-							// UNICODE UNC path, but UNC share
-							curIdx++
-							curSubState = substateUnicodeUNC
-							curState = stateUNC
-							goto loopStart
-						} else {
-							curIdx -= len(node)
-							curState = stateDrive
-							goto loopStart
-						}
-					case substateStart:
-						if node == "?" {
-							// UNICODE UNC has been specified
-							curIdx++
-							curSubState = substateUnicode
-							curState = stateUNC
-							_path.unicode = true
-							goto loopStart
-						}
-						fallthrough
-					case substateUnicodeUNC:
-						fallthrough
-					default:
-						// add component
-						_path.node = node
-						_path.unc = true
-						curState = statePathComponent
-					}
+			if !_path.isSeparator(rune(c)) {
+				// validated once the kind of component is known
+				if curStart < 0 {
+					curStart = curIdx
 				}
-			} else {
-				if _, err := isPathNameLetter(runeArray[curIdx]); err != nil {
-					_path.errs = append(_path.errs, err)
+				break
+			}
+			if curStart < 0 {
+				break
+			}
+
+			// finished a component of the node
+			node, nodeIdx := path[curStart:curIdx], curStart
+			curStart = -1
+
+			switch curSubState {
+			case substateUnicode:
+				if node == "UNC" {
+					// UNICODE UNC path, but UNC share
+					curIdx++
+					curSubState = substateUnicodeUNC
+					goto loopStart
+				} else if _path.setVolume(node) {
+					// a backslash following the volume roots the path
+					_path.absolute = true
+					curState = statePathComponent
+				} else {
+					curIdx = nodeIdx
+					curState = stateDrive
+					goto loopStart
+				}
+			case substateDevice:
+				// a backslash following the device name roots the path
+				_path.setDeviceName(node)
+				_path.absolute = true
+				pipe = _path.IsPipe()
+				streams = !isPipeOrMailslot(node)
+				curState = statePathComponent
+			case substateShare:
+				_path.setShare(node, runeIndex(path, nodeIdx))
+				curState = statePathComponent
+			case substateStart:
+				if node == "?" && path[0] == '\\' && path[1] == '\\' && path[3] == '\\' {
+					// UNICODE UNC has been specified
+					curIdx++
+					curSubState = substateUnicode
+					_path.unicode = true
+					goto loopStart
+				} else if node == "." || node == "?" {
+					// Win32 device namespace has been specified; as
+					// forward slashes never specify a UNICODE path
+					curIdx++
+					curSubState = substateDevice
+					_path.devicePath = true
+					goto loopStart
 				}
-				curStack = append(curStack, runeArray[curIdx])
+				fallthrough
+			case substateUnicodeUNC:
+				fallthrough
+			default:
+				// add component
+				_path.validateName(node, runeIndex(path, nodeIdx), -1)
+				_path.node = node
+				_path.unc = true
+				curIdx++
+				curSubState = substateShare
+				goto loopStart
 			}
 		}
 
 		curIdx++
 	}
 
-	// If a last curStack is present, it is actually the filename being accessed or a final dir
-	if len(curStack) > 0 {
-		for _, c := range curStack {
-			if _, err := isPathNameLetter(c); err != nil {
-				_path.errs = append(_path.errs, err)
+	last := ""
+	if curStart >= 0 {
+		last = path[curStart:]
+	}
+
+	// A device name without a trailing backslash refers to the device itself
+	if curState == stateUNC && curSubState == substateDevice && len(last) > 0 {
+		_path.setDeviceName(last)
+		last = ""
+	}
+
+	// A namespace prefix alone refers to the root of the local devices
+	if curState == stateUNC && curSubState == substateStart && (last == "." || last == "?") {
+		_path.rootDevice = true
+		_path.unicode = last == "?" && path[0] == '\\' && path[1] == '\\'
+		_path.devicePath = !_path.unicode
+		last = ""
+	}
+
+	// A UNICODE drive or volume without a trailing backslash refers to the volume itself
+	if curState == stateUNC && curSubState == substateUnicode && len(last) == 2 && last[1] == ':' {
+		if d, err := isDriveLetter(rune(last[0])); err == nil {
+			_path.device = driveLetter(d)
+			last = ""
+		}
+	} else if curState == stateUNC && curSubState == substateUnicode && _path.setVolume(last) {
+		last = ""
+	}
+
+	// A UNC path may end with its node or share
+	if curState == stateUNC && len(last) > 0 {
+		switch curSubState {
+		case substateStart, substateUnicodeUNC:
+			_path.validateName(last, runeIndex(path, curStart), -1)
+			_path.node = last
+			_path.unc = true
+			last = ""
+		case substateShare:
+			_path.setShare(last, runeIndex(path, curStart))
+			last = ""
+		}
+	}
+
+	// If a last component is present, it is actually the filename being accessed or a final dir
+	if len(last) > 0 {
+		if compStart < 0 {
+			compStart = curStart
+		}
+		if inStream {
+			i := strings.IndexByte(last, ':')
+			if _path.setStream(last[i+1:], runeIndex(path, streamIdx)+1) {
+				last = last[:i]
 			}
 		}
-		_path.name = string(curStack)
+		if curState != statePathComponent {
+			// only path components are validated while parsing
+			_path.validateName(last, runeIndex(path, curStart), len(_path.dirs))
+		}
+		_path.name = last
+	}
+
+	if !_path.unicode && strings.ContainsRune(path, '/') {
+		_path.separators = SlashSeparators
+		if strings.ContainsRune(path, '\\') {
+			_path.separators = MixedSeparators
+		}
 	}
 
-	if _path.unicode && len(path) > 32767 {
-		_path.errs = append(_path.errs, errors.New("Path: the UNICODE path exceeds the maximum of 32,767 characters"))
-	} else if !_path.unicode && len(path) > 255 {
-		_path.errs = append(_path.errs, errors.New("Path: the path exceeds the maximum of 255 characters"))
+	if !_path.devicePath && compStart >= 0 {
+		idx := compStart
+		for i, comp := range _path.dirs {
+			_path.checkComponent(path, comp, idx, i)
+			idx += len(comp)
+			for idx < pathLen && _path.isSeparator(rune(path[idx])) {
+				idx++
+			}
+		}
+		if len(_path.name) > 0 {
+			_path.checkComponent(path, _path.name, idx, len(_path.dirs))
+		}
 	}
 
+	_path.checkLength(path, opts.LongPathAware)
+
 	return _path
 }
 
-// ToString returns a fully-qualified representation of the parsed Path.
+// checkComponent records an error when the given component, found at the
+// given byte index of the parsed text, is a reserved name, is too long, or
+// ends with a dot or a space.
+func (p *PathImpl) checkComponent(path, comp string, idx, component int) {
+	if isReservedName(comp) {
+		p.addError(ErrReservedName, runeIndex(path, idx), component, 0)
+	}
+	if i := exceedsAt(comp, maxComponent); i >= 0 {
+		p.addError(ErrTooLong, runeIndex(path, idx)+i, component, 0)
+	}
+	if hasTrailingDotOrSpace(comp) {
+		last, size := utf8.DecodeLastRuneInString(comp)
+		p.addError(ErrTrailingDotOrSpace, runeIndex(path, idx+len(comp)-size), component, last)
+	}
+}
+
+// ToString returns a representation of the parsed Path, which keeps the
+// Kind() of the Path; so a drive-relative ``C:foo'' is not made absolute.
 func (p *PathImpl) ToString() string {
 	var unc bytes.Buffer
-	hasComponents := false
+
+	switch {
+	case p.rootDevice && p.unicode:
+		return "\\\\?"
+	case p.rootDevice:
+		return "\\\\."
+	case p.devicePath:
+		unc.WriteString("\\\\.\\")
+		p.writeDevicePath(&unc)
+		return unc.String()
+	case len(p.ntPrefix) > 0:
+		unc.WriteString(p.ntPrefix)
+		p.writeUnicodeUNC(&unc)
+		return unc.String()
+	case p.unicode:
+		return p.ToUnicodeUNC()
+	}
 
 	if len(p.device) > 0 {
 		unc.WriteString(p.device)
 		unc.WriteString(":")
 	}
-	if len(p.node) > 0 {
-		unc.WriteString("\\\\")
-		unc.WriteString(p.node)
-	}
-	for _, path := range p.dirs {
-		hasComponents = true
-		unc.WriteString("\\")
-		unc.WriteString(path)
-	}
-	if len(p.name) > 0 {
-		hasComponents = true
-		unc.WriteString("\\")
-		unc.WriteString(p.name)
-	}
-	if !hasComponents {
+	if p.unc {
+		unc.WriteString(p.uncRoot())
+		if len(p.dirs) > 0 || len(p.name) > 0 {
+			unc.WriteString("\\")
+		}
+	} else if p.absolute {
 		unc.WriteString("\\")
 	}
+	unc.WriteString(p.joinComponents())
+	p.writeStream(&unc)
 
 	return unc.String()
 }
@@ -266,22 +491,44 @@ func (p *PathImpl) ToUnicodeUNC() string {
 	var unc bytes.Buffer
 
 	unc.WriteString("\\\\?\\")
+	if p.isNTDevice() {
+		// reach into the NT object manager from the Win32 namespace
+		unc.WriteString("GLOBALROOT")
+		unc.WriteString(p.ntPrefix)
+	}
+	p.writeUnicodeUNC(&unc)
+
+	return unc.String()
+}
+
+// writeUnicodeUNC writes the parsed Path, without the leading namespace
+// prefix, as used by UNICODE and NT object manager paths.
+func (p *PathImpl) writeUnicodeUNC(unc *bytes.Buffer) {
+	if p.devicePath || p.isNTDevice() || p.volume != nil {
+		p.writeDevicePath(unc)
+		return
+	}
 	if len(p.device) > 0 {
 		unc.WriteString(p.device)
-		unc.WriteString(":\\")
+		unc.WriteString(":")
+		if p.absolute || !p.unicode {
+			// a parsed UNICODE drive without a backslash is the volume itself
+			unc.WriteString("\\")
+		}
 	}
 	if len(p.node) > 0 {
 		unc.WriteString("UNC\\")
-		unc.WriteString(p.node)
-		unc.WriteString("\\")
+		unc.WriteString(p.uncRoot()[2:])
+		if len(p.dirs) > 0 || len(p.name) > 0 {
+			unc.WriteString("\\")
+		}
 	}
 	for _, path := range p.dirs {
 		unc.WriteString(path)
 		unc.WriteString("\\")
 	}
 	unc.WriteString(p.name)
-
-	return unc.String()
+	p.writeStream(unc)
 }
 
 // IsDirectoryExists checks whether the Path refers to an existing directory.
@@ -317,27 +564,33 @@ func (p *PathImpl) IsLocal() bool {
 
 // MakeAbsolute checks whether the Path refers to a relative location on the
 // current machine. If so, it non-destructively converts the relative location
-// to an absolute one by querying the path through the operating system.
+// to an absolute one, by resolving it against the ProcessDirectories(). When
+// no current directory is available, the Path is returned as-is.
+//
+// See FullPath() for resolving against other CurrentDirectories.
 //
 // Because MakeAbsolute is non-destructive, the returned pointer to PathImpl
-// may NOT be the same as called with!
+// may NOT be the same as called with! An already fully-qualified Path is
+// returned as-is.
 func (p *PathImpl) MakeAbsolute() *PathImpl {
-	if !p.unc && !p.absolute {
-		if newPath, err := syscall.FullPath(p.ToString()); err == nil {
-			return Path(newPath)
+	if !p.isFullyQualified() {
+		if newPath, err := p.FullPath(ProcessDirectories()); err == nil {
+			return newPath
 		}
 	}
 	return p
 }
 
-// MakeDirectory checks whether the Path has a Name(). If so, the
-// Name() is added to the set of Dirs() and is cleared.
+// MakeDirectory checks whether the Path has a Name(). If so, a new Path is
+// returned, with the Name() added to the set of Dirs() and cleared.
 func (p *PathImpl) MakeDirectory() *PathImpl {
-	if len(p.name) > 0 {
-		p.dirs = append(p.dirs, p.name)
-		p.name = ""
+	if len(p.name) == 0 {
+		return p
 	}
-	return p
+	dir := p.clone()
+	dir.dirs = append(dir.dirs, dir.name)
+	dir.name = ""
+	return dir
 }
 
 // Node returns the server name from a parsed UNC path.
@@ -361,6 +614,7 @@ func (p *PathImpl) Dirs() []string {
 }
 
 // Errors returns an array of all parse and validation errors encountered when parsing.
+// Each error is a *PathError, describing where and why validation failed.
 func (p *PathImpl) Errors() []error {
 	return p.errs
 }
@@ -375,13 +629,42 @@ func (p *PathImpl) Errors() []error {
 //	3. UNC file or directory
 //	4. UNICODE absolute file or directory
 //	5. UNICODE UNC file or directory
+//	6. Win32 device namespace, such as a named pipe or physical disk
+//	7. NT object manager namespace, such as ``\??\C:\''; see ParseNT()
+//	   for ``\Device\''
+//	8. UNICODE volume GUID file or directory
+//
+// Just as the Win32 layer, forward slashes are accepted as separators;
+// except within UNICODE paths.
 //
 // Errors are collected during the parsing, for all possible
 // validation errors describe by the referenced MSDN article later
 // described.
 //
+// Lengths are counted in UTF-16 code units, as Windows does; a path which
+// is not UNICODE is limited to MAX_PATH, unless parsed by ParseWith() to be
+// LongPathAware.
+//
 // See also MSDN, ``Naming Files, Paths, and Namespaces,''
 // https://msdn.microsoft.com/en-us/library/windows/desktop/aa365247(v=vs.85).aspx
 func Path(path string) *PathImpl {
-	return newPathImpl(path)
+	return newPathImpl(path, PathOptions{})
+}
+
+// PathOptions alter how ParseWith() parses a path.
+type PathOptions struct {
+	// LongPathAware lifts the MAX_PATH limit from paths which are not
+	// UNICODE, just as Windows does for applications declaring
+	// ``longPathAware'' in their manifest, once long paths are enabled for
+	// the system.
+	LongPathAware bool
+	// NTDevices accepts NT object manager paths rooted at a device, as
+	// ParseNT() does.
+	NTDevices bool
+}
+
+// ParseWith parses a path just as Path() does, altered by the given
+// options; which the Path keeps, for UnmarshalText().
+func ParseWith(path string, opts PathOptions) *PathImpl {
+	return newPathImpl(path, opts)
 }
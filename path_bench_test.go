@@ -0,0 +1,65 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package windows_test
+
+import (
+	"testing"
+
+	"gitlab.com/jbenden/windows"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var benchmarkPaths = []struct {
+	name string
+	path string
+}{
+	{"Relative", "Users\\joe\\Documents\\report.pdf"},
+	{"DriveAbsolute", "C:\\Windows\\System32\\drivers\\etc\\hosts"},
+	{"UNC", "\\\\peaches\\msys64\\home\\joe\\.bashrc"},
+	{"Unicode", "\\\\?\\C:\\Program Files\\Common Files\\System\\ado\\msado15.dll"},
+	{"Volume", "\\\\?\\Volume{6a4b3c2d-1e0f-11e7-8c3f-806e6f6e6963}\\Windows\\notepad.exe"},
+	{"Stream", "C:\\Users\\joe\\Downloads\\setup.exe:Zone.Identifier:$DATA"},
+	{"NonASCII", "C:\\Users\\J\u00f6rg\\\u00c9t\u00e9\\\u65e5\u672c\u8a9e.txt"},
+}
+
+func BenchmarkPath(b *testing.B) {
+	for _, bm := range benchmarkPaths {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				windows.Path(bm.path)
+			}
+		})
+	}
+}
+
+var _ = Describe("Parser allocations", func() {
+	DescribeTable("when parsing a path",
+		func(target string, allocs int) {
+			Expect(testing.AllocsPerRun(100, func() {
+				windows.Path(target)
+			})).To(BeNumerically("<=", allocs))
+		},
+		Entry("a file name", "report.pdf", 1),
+		Entry("a drive absolute path", benchmarkPaths[1].path, 2),
+		Entry("a UNC path", benchmarkPaths[2].path, 2),
+		Entry("a UNICODE path", benchmarkPaths[3].path, 2),
+		Entry("a non-ASCII path", benchmarkPaths[6].path, 2),
+	)
+})
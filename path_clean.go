@@ -0,0 +1,72 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package windows
+
+// collapseDots lexically removes "." components and resolves ".."
+// components against their parent. When not rooted, leading ".."
+// components are kept, as they refer to a location above the unknown
+// current directory.
+func collapseDots(comps []string, rooted bool) []string {
+	out := make([]string, 0, len(comps))
+	for _, comp := range comps {
+		switch {
+		case comp == "" || comp == ".":
+		case comp == "..":
+			switch {
+			case len(out) > 0 && out[len(out)-1] != "..":
+				out = out[:len(out)-1]
+			case !rooted:
+				out = append(out, comp)
+			}
+		default:
+			out = append(out, comp)
+		}
+	}
+	return out
+}
+
+// Clean returns a new Path, with all "." components removed and all ".."
+// components resolved against their parent directory by purely lexical
+// processing, following the rules of the Win32 layer.
+//
+// A ".." component never climbs above the root of a drive or the
+// ``\\server\share'' of a UNC path. UNICODE paths are returned unchanged,
+// as Windows passes them to the file system without normalization.
+func (p *PathImpl) Clean() *PathImpl {
+	if p.unicode {
+		return p
+	}
+
+	comps := collapseDots(p.components(), p.absolute || p.unc || p.devicePath)
+
+	clean := p.clone()
+	clean.dirs = nil
+	clean.name = ""
+	if len(p.name) > 0 && len(comps) > 0 {
+		clean.name = comps[len(comps)-1]
+		comps = comps[:len(comps)-1]
+	}
+	if len(comps) > 0 {
+		clean.dirs = comps
+	}
+	if len(comps) == 0 && len(clean.name) == 0 && !clean.absolute && !clean.unc && !clean.devicePath && len(clean.device) == 0 {
+		// an empty relative path refers to the current directory
+		clean.name = "."
+	}
+
+	return clean
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package windows_test
+
+import (
+	"gitlab.com/jbenden/windows"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Clean", func() {
+	DescribeTable("when dot components are present",
+		func(target string, dirs []string, name string) {
+			subject := windows.Path(target).Clean()
+
+			Expect(subject).ShouldNot(BeNil())
+			Expect(subject.Dirs()).To(BeEquivalentTo(dirs))
+			Expect(subject.Name()).To(Equal(name))
+		},
+		Entry("an absolute path", "C:\\a\\.\\b\\..\\c", []string{"a"}, "c"),
+		Entry("an absolute path climbing above the drive", "C:\\..\\..\\a", []string(nil), "a"),
+		Entry("a root-relative path climbing above the root", "\\..\\a", []string(nil), "a"),
+		Entry("a UNC path climbing above the share", "\\\\peaches\\msys64\\..\\x", []string(nil), "x"),
+		Entry("a UNC path resolving to the share", "\\\\peaches\\msys64\\home\\..\\..", []string(nil), ""),
+		Entry("a relative path climbing above the current directory", "..\\a\\..\\..\\b", []string{"..", ".."}, "b"),
+		Entry("a drive-relative path with a trailing backslash", "C:a\\..\\..\\b\\", []string{"..", "b"}, ""),
+		Entry("a relative path resolving to nothing", "a\\..", []string(nil), "."),
+		Entry("a UNICODE path", "\\\\?\\C:\\a\\..\\b", []string{"a", ".."}, "b"),
+	)
+
+	Context("when cleaning a path", func() {
+		It("should not modify the original path", func() {
+			subject := windows.Path("C:\\a\\..\\b")
+			subject.Clean()
+
+			Expect(subject.Dirs()).To(BeEquivalentTo([]string{"a", ".."}))
+		})
+
+		It("should generate the cleaned UNICODE UNC path", func() {
+			Expect(windows.Path("C:\\a\\.\\b\\..\\c").Clean().ToUnicodeUNC()).To(Equal("\\\\?\\C:\\a\\c"))
+		})
+	})
+})
@@ -0,0 +1,128 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package windows
+
+import (
+	"strings"
+	"unicode/utf16"
+)
+
+// compareUpcase compares two strings by the ordinal of their upper cased
+// UTF-16 code units, as Windows compares file names. Unlike
+// strings.EqualFold, characters which only share a case folding, such as
+// the Kelvin sign and ``K'', are different.
+func compareUpcase(a, b string) int {
+	ua, ub := utf16.Encode([]rune(a)), utf16.Encode([]rune(b))
+	for i := 0; i < len(ua) && i < len(ub); i++ {
+		ca, cb := upcase(rune(ua[i])), upcase(rune(ub[i]))
+		switch {
+		case ca < cb:
+			return -1
+		case ca > cb:
+			return 1
+		}
+	}
+	switch {
+	case len(ua) < len(ub):
+		return -1
+	case len(ua) > len(ub):
+		return 1
+	}
+	return 0
+}
+
+// equalUpcase determines if two strings are equal, ignoring case as
+// Windows does.
+func equalUpcase(a, b string) bool {
+	return compareUpcase(a, b) == 0
+}
+
+// isWin32Safe determines if a UNICODE drive or UNC path names the same file
+// once the ``\\?\'' prefix is removed; that is, if none of its components
+// would be changed by the Win32 normalization.
+func (p *PathImpl) isWin32Safe() bool {
+	if !p.unicode || len(p.ntPrefix) > 0 || p.devicePath || p.volume != nil || (len(p.device) == 0 && !p.unc) {
+		return false
+	}
+	return p.hasWin32SafeComponents()
+}
+
+// hasWin32SafeComponents determines if none of the components of the Path
+// would be changed, or split, by the Win32 normalization.
+func (p *PathImpl) hasWin32SafeComponents() bool {
+	for _, comp := range p.components() {
+		if comp == "." || comp == ".." || hasTrailingDotOrSpace(comp) || isReservedName(comp) || strings.ContainsRune(comp, '/') {
+			return false
+		}
+	}
+	return true
+}
+
+// compareForm returns the Path as compared; normalized as the Win32 layer
+// would, without the ``\\?\'' prefix when safe, and without a trailing
+// backslash.
+func (p *PathImpl) compareForm() *PathImpl {
+	q := p.Clean().TrimTrailing().clone()
+	if q.isWin32Safe() {
+		q.unicode = false
+	}
+	if len(q.name) == 0 && len(q.dirs) > 0 {
+		q.name = q.dirs[len(q.dirs)-1]
+		q.dirs = q.dirs[:len(q.dirs)-1]
+	}
+	return q
+}
+
+// Compare returns an integer comparing two paths, ignoring case with the
+// NTFS upper case mapping of each UTF-16 code unit. The result will be 0
+// if p and other are equal, -1 if p sorts before other, and +1 otherwise.
+//
+// Both paths are compared as the Win32 layer would normalize them, so the
+// separator style, a trailing backslash, and ``.'' or ``..'' components
+// are ignored; as is the ``\\?\'' prefix, when removing it is safe.
+func (p *PathImpl) Compare(other *PathImpl) int {
+	return compareUpcase(p.compareForm().ToString(), other.compareForm().ToString())
+}
+
+// Equal determines if two paths are equal, as described by Compare().
+func (p *PathImpl) Equal(other *PathImpl) bool {
+	return p.Compare(other) == 0
+}
+
+// HasPrefix determines if the Path is the prefix Path, or is located
+// inside of it. Components are compared whole, so ``C:\foobar'' is not
+// inside of ``C:\foo''; and are compared as described by Compare().
+func (p *PathImpl) HasPrefix(prefix *PathImpl) bool {
+	path, pre := p.compareForm(), prefix.compareForm()
+	if len(pre.stream) > 0 || len(pre.streamType) > 0 {
+		return path.Equal(pre)
+	}
+
+	pathComps, preComps := path.components(), pre.components()
+	if len(preComps) > len(pathComps) {
+		return false
+	}
+	for i, comp := range preComps {
+		if !equalUpcase(comp, pathComps[i]) {
+			return false
+		}
+	}
+
+	path.dirs, path.name, path.stream, path.streamType = nil, "", "", ""
+	pre.dirs, pre.name = nil, ""
+	return equalUpcase(path.ToString(), pre.ToString())
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package windows_test
+
+import (
+	"gitlab.com/jbenden/windows"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Compare", func() {
+	DescribeTable("when comparing paths",
+		func(a, b string, expected int) {
+			Expect(windows.Path(a).Compare(windows.Path(b))).To(Equal(expected))
+			Expect(windows.Path(b).Compare(windows.Path(a))).To(Equal(-expected))
+			Expect(windows.Path(a).Equal(windows.Path(b))).To(Equal(expected == 0))
+		},
+		Entry("differing case", "C:\\Foo", "c:\\foo", 0),
+		Entry("accented letters of differing case", "C:\\\u00c9t\u00e9", "c:\\\u00e9T\u00c9", 0),
+		Entry("greek letters of differing case", "C:\\\u03a3\u03c9", "C:\\\u03c3\u03a9", 0),
+		Entry("forward slashes", "C:/Windows/System32", "C:\\Windows\\System32", 0),
+		Entry("a trailing backslash", "C:\\Windows\\", "C:\\Windows", 0),
+		Entry("dot components", "C:\\Windows\\.\\Temp\\..\\System32", "C:\\Windows\\System32", 0),
+		Entry("a UNICODE prefix", "\\\\?\\C:\\Windows", "C:\\windows", 0),
+		Entry("a UNICODE UNC prefix", "\\\\?\\UNC\\Peaches\\msys64\\home", "\\\\peaches\\MSYS64\\home", 0),
+		Entry("a UNICODE prefix keeping trailing dots", "\\\\?\\C:\\file.", "C:\\file.", 1),
+		Entry("cyrillic letters of differing case", "C:\\\u0434\u0451", "C:\\\u0414\u0401", 0),
+		Entry("armenian letters of differing case", "C:\\\u0561", "C:\\\u0531", 0),
+		Entry("georgian letters, without a case in $UpCase", "C:\\\u10d0", "C:\\\u1c90", -1),
+		Entry("the turned h, without a case in $UpCase", "C:\\\u0265", "C:\\\ua78d", -1),
+		Entry("the micro sign, without a case in $UpCase", "C:\\\u00b5", "C:\\\u039c", -1),
+		Entry("the dotless i, without a case in $UpCase", "C:\\\u0131", "C:\\I", 1),
+		Entry("the Kelvin sign", "C:\\\u212a", "C:\\k", 1),
+		Entry("the sharp s", "C:\\\u00df", "C:\\\u1e9e", -1),
+		Entry("letters outside the Basic Multilingual Plane", "C:\\\U00010428", "C:\\\U00010400", 1),
+		Entry("different names", "C:\\a", "C:\\b", -1),
+		Entry("a shorter path", "C:\\a", "C:\\a\\b", -1),
+		Entry("different drives", "C:\\a", "D:\\a", -1),
+		Entry("different streams", "C:\\file:one", "C:\\file:two", -1),
+	)
+
+	DescribeTable("when checking for a prefix",
+		func(target, prefix string, expected bool) {
+			Expect(windows.Path(target).HasPrefix(windows.Path(prefix))).To(Equal(expected))
+		},
+		Entry("the same path", "C:\\Windows", "c:\\windows", true),
+		Entry("a child", "C:\\Windows\\System32\\drivers", "C:\\WINDOWS\\", true),
+		Entry("the root of the drive", "C:\\Windows", "C:\\", true),
+		Entry("a partial component", "C:\\foobar", "C:\\foo", false),
+		Entry("a different drive", "D:\\Windows", "C:\\", false),
+		Entry("a parent", "C:\\Windows", "C:\\Windows\\System32", false),
+		Entry("a UNICODE prefix", "\\\\?\\C:\\Windows\\System32", "C:\\Windows", true),
+		Entry("a UNC share", "\\\\peaches\\msys64\\home", "\\\\PEACHES\\msys64", true),
+		Entry("a different UNC share", "\\\\peaches\\msys64\\home", "\\\\peaches\\msys", false),
+		Entry("dot components", "C:\\Windows\\..\\Users", "C:\\Windows", false),
+		Entry("a relative path", "a\\b", "A", true),
+		Entry("a relative and an absolute path", "a\\b", "\\a", false),
+	)
+
+	Context("when making a path relative", func() {
+		It("should ignore case as Windows does", func() {
+			rel, err := windows.Rel(windows.Path("C:\\\u00c9t\u00e9"), windows.Path("c:\\\u00e9T\u00c9\\file"))
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(rel.ToString()).To(Equal("file"))
+		})
+	})
+})
@@ -0,0 +1,169 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package windows
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// CygwinConfig describes a Cygwin or MSYS2 installation, for translating
+// paths with ToCygwin() and FromCygwin().
+type CygwinConfig struct {
+	// Prefix is the cygdrive prefix, under which the drives are mounted; by
+	// default, ``/cygdrive/''. MSYS2 mounts the drives under ``/''.
+	Prefix string
+	// Root is the Windows directory mounted as ``/''; such as
+	// ``C:\cygwin64'' or ``C:\msys64''. It is required for translating a
+	// POSIX path outside of the drives into a Windows path.
+	Root string
+}
+
+// prefix returns the cygdrive prefix, ending with a slash.
+func (cfg *CygwinConfig) prefix() string {
+	if cfg == nil || len(cfg.Prefix) == 0 {
+		return "/cygdrive/"
+	}
+	if !strings.HasSuffix(cfg.Prefix, "/") {
+		return cfg.Prefix + "/"
+	}
+	return cfg.Prefix
+}
+
+// encodeCygwin maps each character of a POSIX name which Windows does not
+// permit in names into the U+F000 private use range, just as Cygwin does;
+// including any trailing dots and spaces.
+func encodeCygwin(name string) string {
+	name = encodePrivateUse(name)
+	if name == "." || name == ".." {
+		return name
+	}
+	trimmed := strings.TrimRight(name, ". ")
+	if len(trimmed) == len(name) {
+		return name
+	}
+
+	var b strings.Builder
+	b.WriteString(trimmed)
+	for _, c := range name[len(trimmed):] {
+		b.WriteRune(c + privateUseBase)
+	}
+	return b.String()
+}
+
+// decodeCygwin reverses encodeCygwin().
+func decodeCygwin(name string) string {
+	name = decodePrivateUse(name)
+	end := len(name)
+	for end > 0 {
+		c, size := utf8.DecodeLastRuneInString(name[:end])
+		if c != privateUseBase+'.' && c != privateUseBase+' ' {
+			break
+		}
+		end -= size
+	}
+	if end == len(name) {
+		return name
+	}
+
+	var b strings.Builder
+	b.WriteString(name[:end])
+	for _, c := range name[end:] {
+		b.WriteRune(c - privateUseBase)
+	}
+	return b.String()
+}
+
+// ToCygwin translates the Path into a Cygwin or MSYS2 path, just as
+// ``cygpath -u'' does; such as ``/cygdrive/c/src'' for ``C:\src'',
+// ``/usr/bin'' for ``C:\msys64\usr\bin'' within the Root, or
+// ``//server/share'' for ``\\server\share''. A relative Path remains
+// relative. The cfg may be nil.
+//
+// ErrNotRepresentable is returned for a drive-relative or root-relative
+// Path, a device or volume Path, a stream, or a UNICODE name holding a
+// forward slash.
+func (p *PathImpl) ToCygwin(cfg *CygwinConfig) (string, error) {
+	if (p.unicode && !p.isWin32Safe()) || p.hasSlash() || len(p.stream) > 0 || len(p.streamType) > 0 {
+		return "", ErrNotRepresentable
+	}
+
+	if cfg != nil && len(cfg.Root) > 0 && p.isFullyQualified() {
+		root := Path(cfg.Root)
+		if p.HasPrefix(root) {
+			// the components below the Root, keeping a trailing separator
+			// just as the drive form does
+			rel, n := p.Clean().clone(), len(root.compareForm().components())
+			if n > len(rel.dirs) {
+				return "/", nil
+			}
+			rel.dirs = rel.dirs[n:]
+			return "/" + rel.joinPOSIX(decodeCygwin), nil
+		}
+	}
+
+	switch {
+	case p.unc:
+		unc := "//" + decodeCygwin(p.node)
+		if len(p.share) > 0 {
+			unc += "/" + decodeCygwin(p.share)
+		}
+		if len(p.dirs) > 0 || len(p.name) > 0 {
+			unc += "/" + p.joinPOSIX(decodeCygwin)
+		}
+		return unc, nil
+	case len(p.device) > 0 && p.absolute && !p.devicePath:
+		return cfg.prefix() + strings.ToLower(p.device) + "/" + p.joinPOSIX(decodeCygwin), nil
+	case len(p.device) == 0 && !p.absolute && !p.devicePath:
+		return p.joinPOSIX(decodeCygwin), nil
+	}
+	return "", ErrNotRepresentable
+}
+
+// FromCygwin translates a Cygwin or MSYS2 path into a Path, just as
+// ``cygpath -w'' does; such as ``C:\src'' for ``/cygdrive/c/src'', or
+// ``C:\msys64\usr\bin'' for ``/usr/bin'' with the Root ``C:\msys64''. A
+// relative path remains relative. Characters which Windows does not permit
+// in names, along with trailing dots and spaces, are mapped into the U+F000
+// private use range, as Cygwin does. The cfg may be nil.
+//
+// ErrNotRepresentable is returned for a path outside of the drives, when
+// the cfg does not name the Root.
+func FromCygwin(path string, cfg *CygwinConfig) (*PathImpl, error) {
+	switch {
+	case strings.HasPrefix(path, "//"):
+		comps, dir := splitPOSIX(path)
+		if len(comps) == 0 {
+			return nil, ErrNotRepresentable
+		}
+		return fromPOSIX("\\\\", comps, dir, encodeCygwin), nil
+	case !strings.HasPrefix(path, "/"):
+		comps, dir := splitPOSIX(path)
+		return fromPOSIX("", comps, dir, encodeCygwin), nil
+	}
+
+	if drive, rest, ok := posixDrive(path, cfg.prefix()); ok {
+		comps, dir := splitPOSIX(rest)
+		return fromPOSIX(drive+":\\", comps, dir, encodeCygwin), nil
+	}
+
+	if cfg == nil || len(cfg.Root) == 0 {
+		return nil, ErrNotRepresentable
+	}
+	comps, dir := splitPOSIX(path)
+	return fromPOSIX(strings.TrimRight(cfg.Root, "\\/")+"\\", comps, dir, encodeCygwin), nil
+}
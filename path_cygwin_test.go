@@ -0,0 +1,106 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package windows_test
+
+import (
+	"gitlab.com/jbenden/windows"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cygwin", func() {
+	cygwin := &windows.CygwinConfig{Root: "C:\\cygwin64"}
+	msys2 := &windows.CygwinConfig{Prefix: "/", Root: "C:\\msys64\\"}
+
+	DescribeTable("when translating to Cygwin",
+		func(target string, cfg *windows.CygwinConfig, expected string) {
+			posix, err := windows.Path(target).ToCygwin(cfg)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(posix).To(Equal(expected))
+		},
+		Entry("a drive path", "C:\\src\\app", nil, "/cygdrive/c/src/app"),
+		Entry("a drive root", "D:\\", nil, "/cygdrive/d/"),
+		Entry("an MSYS2 drive path", "C:\\src\\app", msys2, "/c/src/app"),
+		Entry("a path within the root", "C:\\cygwin64\\usr\\bin", cygwin, "/usr/bin"),
+		Entry("a path within the root of differing case", "c:\\MSYS64\\home\\joe", msys2, "/home/joe"),
+		Entry("the root", "C:\\msys64", msys2, "/"),
+		Entry("the root as a directory", "C:\\msys64\\", msys2, "/"),
+		Entry("a directory within the root", "C:\\msys64\\usr\\", msys2, "/usr/"),
+		Entry("a directory within the root after cleaning", "C:\\msys64\\tmp\\..\\usr\\", msys2, "/usr/"),
+		Entry("a path beside the root", "C:\\msys64-old\\bin", msys2, "/c/msys64-old/bin"),
+		Entry("a UNC path", "\\\\peaches\\msys64\\home", nil, "//peaches/msys64/home"),
+		Entry("a UNC share", "\\\\peaches\\msys64", nil, "//peaches/msys64"),
+		Entry("a UNICODE path", "\\\\?\\C:\\src", nil, "/cygdrive/c/src"),
+		Entry("a relative path", "src\\app", nil, "src/app"),
+		Entry("a mapped character", "C:\\a\uf03ab\uf02a", nil, "/cygdrive/c/a:b*"),
+		Entry("a mapped trailing dot", "C:\\file\uf02e", nil, "/cygdrive/c/file."),
+	)
+
+	DescribeTable("when a path is not representable in Cygwin",
+		func(target string) {
+			_, err := windows.Path(target).ToCygwin(msys2)
+
+			Expect(err).To(MatchError(windows.ErrNotRepresentable))
+		},
+		Entry("a drive-relative path", "C:src"),
+		Entry("a root-relative path", "\\src"),
+		Entry("a stream", "C:\\file:stream"),
+		Entry("a device path", "\\\\.\\COM1"),
+		Entry("a UNICODE path keeping a trailing dot", "\\\\?\\C:\\file."),
+		Entry("a UNICODE name holding a slash", "\\\\?\\C:\\a/b"),
+	)
+
+	DescribeTable("when translating from Cygwin",
+		func(target string, cfg *windows.CygwinConfig, expected string) {
+			subject, err := windows.FromCygwin(target, cfg)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(subject.Errors()).To(BeEmpty())
+			Expect(subject.ToString()).To(Equal(expected))
+		},
+		Entry("a cygdrive path", "/cygdrive/c/src/app", nil, "C:\\src\\app"),
+		Entry("a cygdrive root", "/cygdrive/d", nil, "D:\\"),
+		Entry("an MSYS2 drive path", "/c/src/app", msys2, "C:\\src\\app"),
+		Entry("a path within the root", "/usr/bin", cygwin, "C:\\cygwin64\\usr\\bin"),
+		Entry("an MSYS2 path within the root", "/home/joe/", msys2, "C:\\msys64\\home\\joe\\"),
+		Entry("the root", "/", msys2, "C:\\msys64\\"),
+		Entry("a UNC path", "//peaches/msys64/home", nil, "\\\\peaches\\msys64\\home"),
+		Entry("a relative path", "src/app", nil, "src\\app"),
+		Entry("a character Windows does not permit", "/cygdrive/c/a:b*", nil, "C:\\a\uf03ab\uf02a"),
+		Entry("a trailing dot and space", "/cygdrive/c/file. ", nil, "C:\\file\uf02e\uf020"),
+		Entry("a parent directory", "../app", nil, "..\\app"),
+	)
+
+	Context("when the root is unknown", func() {
+		It("should not translate a path outside of the drives", func() {
+			_, err := windows.FromCygwin("/usr/bin", nil)
+
+			Expect(err).To(MatchError(windows.ErrNotRepresentable))
+		})
+	})
+
+	Context("when round-tripping", func() {
+		It("should restore the original path", func() {
+			subject, err := windows.FromCygwin("/cygdrive/c/what?/end.", nil)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(subject.ToCygwin(nil)).To(Equal("/cygdrive/c/what?/end."))
+		})
+	})
+})
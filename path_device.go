@@ -0,0 +1,81 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package windows
+
+import (
+	"bytes"
+	"strings"
+)
+
+// setDeviceName records the name of a Win32 device namespace path; such as
+// ``PhysicalDrive0'', ``pipe'' or ``C:''. A drive letter is also recorded as
+// the Device().
+func (p *PathImpl) setDeviceName(name string) {
+	p.deviceName = name
+	if len(name) == 2 && name[1] == ':' {
+		if c, err := isDriveLetter(rune(name[0])); err == nil {
+			p.device = string(c)
+		}
+	}
+}
+
+// writeDevicePath writes the device name and all components of a Win32
+// device namespace path, without the leading namespace prefix.
+func (p *PathImpl) writeDevicePath(buf *bytes.Buffer) {
+	buf.WriteString(p.deviceName)
+	if p.absolute {
+		buf.WriteString("\\")
+	}
+	buf.WriteString(p.joinComponents())
+	p.writeStream(buf)
+}
+
+// IsDevice checks whether the Path refers to the Win32 device namespace;
+// such as ``\\.\PhysicalDrive0'' or ``\\.\pipe\name''.
+func (p *PathImpl) IsDevice() bool {
+	return p.devicePath
+}
+
+// DeviceName returns the name of the device from a parsed Win32 device
+// namespace path; such as ``PhysicalDrive0'', ``COM12'', ``pipe'' or ``C:''.
+// For an NT path rooted at ``\Device\'', it is the name of the NT device;
+// such as ``HarddiskVolume3''.
+func (p *PathImpl) DeviceName() string {
+	return p.deviceName
+}
+
+// isPipeOrMailslot determines if the given Win32 or NT device name refers
+// to named pipes or mailslots; whose names may hold a colon, rather than
+// naming a stream.
+func isPipeOrMailslot(deviceName string) bool {
+	return strings.EqualFold(deviceName, "pipe") || strings.EqualFold(deviceName, "NamedPipe") ||
+		strings.EqualFold(deviceName, "mailslot")
+}
+
+// IsPipe checks whether the Path refers to a named pipe.
+func (p *PathImpl) IsPipe() bool {
+	return p.devicePath && strings.EqualFold(p.deviceName, "pipe")
+}
+
+// PipeName returns the name of the pipe from a parsed named pipe path; such
+// as ``myservice'' from ``\\.\pipe\myservice''.
+func (p *PathImpl) PipeName() string {
+	if !p.IsPipe() {
+		return ""
+	}
+	return strings.Join(p.components(), "\\")
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package windows_test
+
+import (
+	"gitlab.com/jbenden/windows"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Device", func() {
+	DescribeTable("when a Win32 device namespace path is present",
+		func(target string, deviceName string, device string, unicode string) {
+			subject := windows.Path(target)
+
+			Expect(subject).ShouldNot(BeNil())
+			Expect(subject.Errors()).To(BeEmpty())
+			Expect(subject.IsDevice()).To(BeTrue())
+			Expect(subject.IsLocal()).To(BeTrue())
+			Expect(subject.Node()).To(BeEmpty())
+			Expect(subject.DeviceName()).To(Equal(deviceName))
+			Expect(subject.Device()).To(Equal(device))
+			Expect(subject.ToString()).To(Equal(target))
+			Expect(subject.ToUnicodeUNC()).To(Equal(unicode))
+		},
+		Entry("a physical drive", "\\\\.\\PhysicalDrive0", "PhysicalDrive0", "", "\\\\?\\PhysicalDrive0"),
+		Entry("a serial port", "\\\\.\\COM12", "COM12", "", "\\\\?\\COM12"),
+		Entry("a volume", "\\\\.\\C:", "C:", "C", "\\\\?\\C:"),
+		Entry("a volume root directory", "\\\\.\\C:\\", "C:", "C", "\\\\?\\C:\\"),
+		Entry("a file on a volume", "\\\\.\\C:\\msys64\\home", "C:", "C", "\\\\?\\C:\\msys64\\home"),
+		Entry("a named pipe", "\\\\.\\pipe\\myservice", "pipe", "", "\\\\?\\pipe\\myservice"),
+	)
+
+	Context("when a named pipe is present", func() {
+		var subject *windows.PathImpl
+
+		BeforeEach(func() {
+			subject = windows.Path("\\\\.\\pipe\\myservice\\instance")
+
+			Expect(subject).ShouldNot(BeNil())
+		})
+
+		It("should be a pipe", func() {
+			Expect(subject.IsPipe()).To(BeTrue())
+		})
+
+		It("should have the correct pipe name", func() {
+			Expect(subject.PipeName()).To(Equal("myservice\\instance"))
+		})
+	})
+
+	Context("when a named pipe holds characters reserved in file names", func() {
+		It("should not have errors", func() {
+			subject := windows.Path("\\\\.\\pipe\\x|y<z>*?")
+
+			Expect(subject.Errors()).To(BeEmpty())
+			Expect(subject.PipeName()).To(Equal("x|y<z>*?"))
+			Expect(subject.ToString()).To(Equal("\\\\.\\pipe\\x|y<z>*?"))
+		})
+
+		It("should not have errors when joined", func() {
+			subject := windows.Path("\\\\.\\pipe").Join("x|y")
+
+			Expect(subject.Errors()).To(BeEmpty())
+			Expect(subject.PipeName()).To(Equal("x|y"))
+		})
+	})
+
+	DescribeTable("when a named pipe holds a colon",
+		func(target string, pipeName string) {
+			subject := windows.Path(target)
+
+			Expect(subject.Errors()).To(BeEmpty())
+			Expect(subject.PipeName()).To(Equal(pipeName))
+			Expect(subject.HasStream()).To(BeFalse())
+			Expect(subject.ToString()).To(Equal(target))
+		},
+		Entry("a single colon", "\\\\.\\pipe\\a:b", "a:b"),
+		Entry("many colons", "\\\\.\\pipe\\a:b:c", "a:b:c"),
+		Entry("a trailing colon", "\\\\.\\pipe\\a:", "a:"),
+	)
+
+	DescribeTable("when a file on a volume has a stream",
+		func(target string) {
+			subject := windows.Path(target)
+
+			Expect(subject.Errors()).To(BeEmpty())
+			Expect(subject.Name()).To(Equal("file.txt"))
+			Expect(subject.Stream()).To(Equal("s"))
+			Expect(subject.ToString()).To(Equal(target))
+		},
+		Entry("a drive", "\\\\.\\C:\\file.txt:s"),
+		Entry("a volume device", "\\\\.\\HarddiskVolume3\\file.txt:s"),
+	)
+
+	Context("when a mailslot holds a colon", func() {
+		It("should not have a stream", func() {
+			subject := windows.Path("\\\\.\\mailslot\\a:b")
+
+			Expect(subject.HasStream()).To(BeFalse())
+			Expect(subject.ToString()).To(Equal("\\\\.\\mailslot\\a:b"))
+		})
+	})
+
+	Context("when a file name holds a reserved character", func() {
+		It("should have an error", func() {
+			subject := windows.Path("\\\\.\\C:\\x|y")
+
+			Expect(subject.Errors()).To(ContainElement(MatchError(windows.ErrReservedChar)))
+		})
+	})
+
+	Context("when a device other than a pipe is present", func() {
+		It("should not have a pipe name", func() {
+			subject := windows.Path("\\\\.\\COM1")
+
+			Expect(subject.IsPipe()).To(BeFalse())
+			Expect(subject.PipeName()).To(BeEmpty())
+		})
+	})
+
+	Context("when a device path is cleaned", func() {
+		It("should not climb above the device", func() {
+			subject := windows.Path("\\\\.\\C:\\a\\..\\..\\b").Clean()
+
+			Expect(subject.ToString()).To(Equal("\\\\.\\C:\\b"))
+		})
+	})
+})
@@ -0,0 +1,175 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package windows
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnsupportedScan indicates a database value of a type other than text
+// was scanned into a Path.
+var ErrUnsupportedScan = errors.New("path: unsupported type for Scan")
+
+// PathInfo is the structured form of a parsed Path, for use with
+// encoding/json; as returned by Info().
+type PathInfo struct {
+	Path   string   `json:"path"`
+	Device string   `json:"device,omitempty"`
+	Node   string   `json:"node,omitempty"`
+	Share  string   `json:"share,omitempty"`
+	Dirs   []string `json:"dirs,omitempty"`
+	Name   string   `json:"name,omitempty"`
+	Kind   PathKind `json:"kind"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Info returns the structured form of the Path.
+func (p *PathImpl) Info() PathInfo {
+	info := PathInfo{
+		Path:   p.ToString(),
+		Device: p.device,
+		Node:   p.node,
+		Share:  p.share,
+		Dirs:   append([]string(nil), p.dirs...),
+		Name:   p.name,
+		Kind:   p.Kind(),
+	}
+	for _, err := range p.errs {
+		info.Errors = append(info.Errors, err.Error())
+	}
+	return info
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the name of the
+// PathKind.
+func (k PathKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, parsing the name of a
+// PathKind.
+func (k *PathKind) UnmarshalText(text []byte) error {
+	for i, name := range pathKindNames {
+		if name == string(text) {
+			*k = PathKind(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("path: unknown path kind %q", text)
+}
+
+// MarshalText implements encoding.TextMarshaler, returning ToString(). A
+// nil Path is empty text.
+func (p *PathImpl) MarshalText() ([]byte, error) {
+	if p == nil {
+		return []byte{}, nil
+	}
+	return []byte(p.ToString()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, replacing the Path
+// with the given text parsed by ParseWith(), using the PathOptions the Path
+// was parsed with; while text rooted at ``\Device\'' is parsed as ParseNT()
+// does. Any validation errors are found in Errors(), rather than returned.
+//
+// The text holds no PathOptions; so a root-relative Win32 path beginning
+// with ``\Device\'' is decoded as an NT path, while a long path decoded into
+// a Path not parsed by ParseWith() to be LongPathAware is limited to
+// MAX_PATH.
+//
+// Unlike every other operation, this modifies the Path; so it must not be
+// used on a Path shared with other goroutines.
+func (p *PathImpl) UnmarshalText(text []byte) error {
+	opts := p.opts
+	if len(text) >= len(ntDevice) && strings.EqualFold(string(text[:len(ntDevice)]), ntDevice) {
+		opts.NTDevices = true
+	}
+	*p = *newPathImpl(string(text), opts)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, encoding the Path as a JSON
+// string, or a nil Path as null. Use Info() for the structured form.
+func (p *PathImpl) MarshalJSON() ([]byte, error) {
+	if p == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(p.ToString())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting either a JSON
+// string or the structured form of Info(); of which only the path is used.
+// A JSON null leaves the Path unchanged.
+//
+// See UnmarshalText() for more.
+func (p *PathImpl) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	var text string
+	if len(data) > 0 && data[0] == '{' {
+		var info struct {
+			Path string `json:"path"`
+		}
+		if err := json.Unmarshal(data, &info); err != nil {
+			return err
+		}
+		text = info.Path
+	} else if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	return p.UnmarshalText([]byte(text))
+}
+
+// Scan implements sql.Scanner, parsing a text column with Path(). A NULL
+// column results in an empty Path, which IsNull() and is stored as NULL by
+// Value().
+//
+// See UnmarshalText() for more.
+func (p *PathImpl) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		return p.UnmarshalText([]byte(v))
+	case []byte:
+		return p.UnmarshalText(v)
+	case nil:
+		*p = PathImpl{opts: p.opts, null: true}
+		return nil
+	}
+	return fmt.Errorf("%w: %T", ErrUnsupportedScan, src)
+}
+
+// Value implements driver.Valuer, storing ToString() as text. A nil Path,
+// or one scanned from NULL, is stored as NULL.
+func (p *PathImpl) Value() (driver.Value, error) {
+	if p.IsNull() {
+		return nil, nil
+	}
+	return p.ToString(), nil
+}
+
+// IsNull checks whether the Path is nil, or was scanned from a NULL column.
+func (p *PathImpl) IsNull() bool {
+	return p == nil || p.null
+}
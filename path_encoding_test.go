@@ -0,0 +1,194 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package windows_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"strings"
+
+	"gitlab.com/jbenden/windows"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var (
+	_ encoding.TextMarshaler   = windows.Path("")
+	_ encoding.TextUnmarshaler = windows.Path("")
+	_ json.Marshaler           = windows.Path("")
+	_ json.Unmarshaler         = windows.Path("")
+	_ sql.Scanner              = windows.Path("")
+	_ driver.Valuer            = windows.Path("")
+)
+
+type config struct {
+	Home  *windows.PathImpl `json:"home"`
+	Cache *windows.PathImpl `json:"cache,omitempty"`
+}
+
+var _ = Describe("Encoding", func() {
+	DescribeTable("when round-tripping through text",
+		func(target string) {
+			text, err := windows.Path(target).MarshalText()
+			Expect(err).ShouldNot(HaveOccurred())
+
+			var subject windows.PathImpl
+			Expect(subject.UnmarshalText(text)).To(Succeed())
+			Expect(subject.ToString()).To(Equal(target))
+		},
+		Entry("an absolute path", "C:\\Windows\\System32"),
+		Entry("a UNC path", "\\\\peaches\\msys64\\home"),
+		Entry("a UNICODE path", "\\\\?\\C:\\dir\\file."),
+		Entry("a relative path", "dir\\file"),
+	)
+
+	Context("when marshalling a nil Path", func() {
+		It("should not panic", func() {
+			var subject *windows.PathImpl
+
+			text, err := subject.MarshalText()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(text).To(BeEmpty())
+
+			data, err := subject.MarshalJSON()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(data)).To(Equal("null"))
+		})
+	})
+
+	Context("when round-tripping an NT device path", func() {
+		It("should remain an NT path", func() {
+			data, err := json.Marshal(config{Home: windows.ParseNT("\\Device\\HarddiskVolume3\\x")})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			var subject config
+			Expect(json.Unmarshal(data, &subject)).To(Succeed())
+			Expect(subject.Home.IsNT()).To(BeTrue())
+			Expect(subject.Home.DeviceName()).To(Equal("HarddiskVolume3"))
+			Expect(subject.Home.Name()).To(Equal("x"))
+		})
+	})
+
+	Context("when round-tripping a long path", func() {
+		It("should keep the options of the Path", func() {
+			long := "C:\\" + strings.Repeat(strings.Repeat("a", 99)+"\\", 3)
+			text, err := windows.ParseWith(long, windows.PathOptions{LongPathAware: true}).MarshalText()
+			Expect(err).ShouldNot(HaveOccurred())
+
+			subject := windows.ParseWith("", windows.PathOptions{LongPathAware: true})
+			Expect(subject.UnmarshalText(text)).To(Succeed())
+			Expect(subject.ToString()).To(Equal(long))
+			Expect(subject.Errors()).To(BeEmpty())
+		})
+	})
+
+	Context("when encoding JSON", func() {
+		It("should encode a JSON string", func() {
+			data, err := json.Marshal(config{Home: windows.Path("C:/Users/joe")})
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(data)).To(MatchJSON(`{"home":"C:\\Users\\joe"}`))
+		})
+
+		It("should decode a JSON string", func() {
+			var subject config
+
+			Expect(json.Unmarshal([]byte(`{"home":"\\\\peaches\\msys64\\home"}`), &subject)).To(Succeed())
+			Expect(subject.Home.Share()).To(Equal("msys64"))
+			Expect(subject.Cache).To(BeNil())
+		})
+
+		It("should decode the structured form", func() {
+			var subject config
+
+			Expect(json.Unmarshal([]byte(`{"home":{"path":"C:\\Users\\joe","kind":"DriveAbsolute"}}`), &subject)).To(Succeed())
+			Expect(subject.Home.ToString()).To(Equal("C:\\Users\\joe"))
+		})
+
+		It("should reject a value which is not a path", func() {
+			var subject config
+
+			Expect(json.Unmarshal([]byte(`{"home":42}`), &subject)).ShouldNot(Succeed())
+		})
+
+		It("should encode the structured form", func() {
+			data, err := json.Marshal(windows.Path("\\\\peaches\\msys64\\home\\nul").Info())
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(data)).To(MatchJSON(`{
+				"path": "\\\\peaches\\msys64\\home\\nul",
+				"node": "peaches",
+				"share": "msys64",
+				"dirs": ["home"],
+				"name": "nul",
+				"kind": "UNCAbsolute",
+				"errors": ["path: a reserved device name is present in component 1 at offset 22"]
+			}`))
+		})
+	})
+
+	Context("when using a database", func() {
+		It("should scan text columns", func() {
+			var subject windows.PathImpl
+
+			Expect(subject.Scan("C:\\Windows")).To(Succeed())
+			Expect(subject.Device()).To(Equal("C"))
+			Expect(subject.Scan([]byte("D:\\Temp"))).To(Succeed())
+			Expect(subject.Device()).To(Equal("D"))
+		})
+
+		It("should scan NULL columns", func() {
+			subject := windows.Path("C:\\Windows")
+
+			Expect(subject.Scan(nil)).To(Succeed())
+			Expect(subject.ToString()).To(BeEmpty())
+			Expect(subject.IsNull()).To(BeTrue())
+		})
+
+		It("should store NULL for a path scanned from NULL", func() {
+			subject := windows.Path("C:\\Windows")
+			Expect(subject.Scan(nil)).To(Succeed())
+
+			Expect(subject.Value()).To(BeNil())
+			Expect(subject.Scan("C:\\Windows")).To(Succeed())
+			Expect(subject.IsNull()).To(BeFalse())
+			Expect(subject.Value()).To(Equal(driver.Value("C:\\Windows")))
+		})
+
+		It("should reject other columns", func() {
+			var subject windows.PathImpl
+
+			Expect(subject.Scan(42)).To(MatchError(windows.ErrUnsupportedScan))
+		})
+
+		It("should store text", func() {
+			value, err := windows.Path("C:/Windows").Value()
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(value).To(Equal(driver.Value("C:\\Windows")))
+		})
+
+		It("should store NULL for a nil path", func() {
+			var subject *windows.PathImpl
+
+			Expect(subject.Value()).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,198 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package windows
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrReservedChar indicates a character reserved by Windows, such as ``<''
+// or ``|'', was used in a file or directory name.
+var ErrReservedChar = errors.New("path: a reserved character is present")
+
+// ErrControlChar indicates a control character, including NULL, was used in
+// a file or directory name.
+var ErrControlChar = errors.New("path: a control character is present")
+
+// ErrTooLong indicates the path exceeds the maximum length Windows permits.
+var ErrTooLong = errors.New("path: the path exceeds the maximum length")
+
+// PathErrorKind classifies the reason a Path failed validation.
+type PathErrorKind int
+
+const (
+	// UnknownError is an error of an unknown kind.
+	UnknownError PathErrorKind = iota
+	// InvalidDriveError is an invalid drive letter.
+	InvalidDriveError
+	// ReservedCharError is a reserved character in a name.
+	ReservedCharError
+	// ControlCharError is a control character in a name.
+	ControlCharError
+	// ReservedNameError is a reserved DOS device name.
+	ReservedNameError
+	// TrailingDotOrSpaceError is a name ending with a dot or space.
+	TrailingDotOrSpaceError
+	// InvalidStreamError is a malformed alternate data stream.
+	InvalidStreamError
+	// InvalidShareError is a malformed UNC share name.
+	InvalidShareError
+	// TooLongError is a path exceeding the maximum length.
+	TooLongError
+	// InvalidShortNameError is a malformed 8.3 short name.
+	InvalidShortNameError
+)
+
+// String returns the name of the error kind.
+func (k PathErrorKind) String() string {
+	switch k {
+	case InvalidDriveError:
+		return "invalid drive"
+	case ReservedCharError:
+		return "reserved character"
+	case ControlCharError:
+		return "control character"
+	case ReservedNameError:
+		return "reserved name"
+	case TrailingDotOrSpaceError:
+		return "trailing dot or space"
+	case InvalidStreamError:
+		return "invalid stream"
+	case InvalidShareError:
+		return "invalid share"
+	case TooLongError:
+		return "too long"
+	case InvalidShortNameError:
+		return "invalid short name"
+	}
+	return "unknown"
+}
+
+// errorKind returns the kind of the given sentinel error.
+func errorKind(err error) PathErrorKind {
+	switch err {
+	case ErrInvalidDrive:
+		return InvalidDriveError
+	case ErrReservedChar:
+		return ReservedCharError
+	case ErrControlChar:
+		return ControlCharError
+	case ErrReservedName:
+		return ReservedNameError
+	case ErrTrailingDotOrSpace:
+		return TrailingDotOrSpaceError
+	case ErrInvalidStream:
+		return InvalidStreamError
+	case ErrInvalidShare:
+		return InvalidShareError
+	case ErrTooLong:
+		return TooLongError
+	case ErrInvalidShortName:
+		return InvalidShortNameError
+	}
+	return UnknownError
+}
+
+// PathError describes where, and why, a Path failed validation. It wraps
+// one of the sentinel errors of this package, so errors.Is() may be used to
+// test for a particular kind of failure.
+type PathError struct {
+	// Kind classifies the failure.
+	Kind PathErrorKind
+	// Offset is the rune offset of the failure within the parsed text, or
+	// -1 when unknown.
+	Offset int
+	// Component is the index of the failing component within Dirs(), where
+	// len(Dirs()) refers to Name(); or -1 when not within a component.
+	Component int
+	// Rune is the offending rune, for failures caused by a single character.
+	Rune rune
+	// Err is the sentinel error describing the failure.
+	Err error
+}
+
+// newPathError returns a PathError wrapping the given sentinel error.
+func newPathError(err error, offset, component int, c rune) *PathError {
+	return &PathError{Kind: errorKind(err), Offset: offset, Component: component, Rune: c, Err: err}
+}
+
+// Error returns a description of the failure and its location.
+func (e *PathError) Error() string {
+	msg := e.Err.Error()
+	if e.Component >= 0 {
+		msg += fmt.Sprintf(" in component %d", e.Component)
+	}
+	if e.Offset >= 0 {
+		msg += fmt.Sprintf(" at offset %d", e.Offset)
+	}
+	if e.Rune != 0 || e.Kind == ControlCharError {
+		msg += fmt.Sprintf(" (%q)", e.Rune)
+	}
+	return msg
+}
+
+// Unwrap returns the sentinel error describing the failure.
+func (e *PathError) Unwrap() error {
+	return e.Err
+}
+
+// addError records a PathError for the given sentinel error.
+func (p *PathImpl) addError(err error, offset, component int, c rune) {
+	p.errs = append(p.errs, newPathError(err, offset, component, c))
+}
+
+// replaceComponentErrors drops the errors of the components from start up
+// to end, which are being replaced; while the errors of later components
+// are moved by shift, with their offsets no longer known.
+func (p *PathImpl) replaceComponentErrors(start, end, shift int) {
+	errs := p.errs
+	p.errs = nil
+	for _, err := range errs {
+		if pe, ok := err.(*PathError); ok && pe.Component >= start {
+			if pe.Component < end {
+				continue
+			}
+			if shift != 0 {
+				moved := *pe
+				moved.Component += shift
+				moved.Offset = -1
+				err = &moved
+			}
+		}
+		p.errs = append(p.errs, err)
+	}
+}
+
+// validateName records an error for each rune of a file or directory name,
+// starting at the given offset, which is not permitted by Windows.
+func (p *PathImpl) validateName(name string, offset, component int) {
+	for _, c := range name {
+		if _, err := isPathNameLetter(c); err != nil {
+			p.addError(err, offset, component, c)
+		}
+		if offset >= 0 {
+			offset++
+		}
+	}
+}
+
+// Err returns every error of the Path joined into a single error, or nil
+// when the Path is valid.
+func (p *PathImpl) Err() error {
+	return errors.Join(p.errs...)
+}
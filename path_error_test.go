@@ -0,0 +1,91 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package windows_test
+
+import (
+	"errors"
+
+	"gitlab.com/jbenden/windows"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PathError", func() {
+	DescribeTable("when a path fails validation",
+		func(target string, kind windows.PathErrorKind, offset, component int, c rune) {
+			subject := windows.Path(target)
+
+			Expect(subject.Errors()).To(HaveLen(1))
+
+			var pathErr *windows.PathError
+			Expect(errors.As(subject.Errors()[0], &pathErr)).To(BeTrue())
+			Expect(pathErr.Kind).To(Equal(kind))
+			Expect(pathErr.Offset).To(Equal(offset))
+			Expect(pathErr.Component).To(Equal(component))
+			Expect(pathErr.Rune).To(Equal(c))
+		},
+		Entry("a reserved character in a directory", "C:\\dir\\a|b\\file", windows.ReservedCharError, 8, 1, '|'),
+		Entry("a control character in a directory", "C:\\a\tb\\file", windows.ControlCharError, 4, 0, '\t'),
+		Entry("a reserved character in a node", "\\\\pea*ches\\share", windows.ReservedCharError, 5, -1, '*'),
+		Entry("a colon in a directory", "C:\\a:b\\file", windows.ReservedCharError, 4, 0, ':'),
+		Entry("a reserved name", "C:\\dir\\nul.txt", windows.ReservedNameError, 7, 1, rune(0)),
+		Entry("a trailing space", "C:\\dir \\file", windows.TrailingDotOrSpaceError, 6, 0, ' '),
+		Entry("a malformed stream type", "C:\\file:s:$BOGUS", windows.InvalidStreamError, 10, 0, rune(0)),
+		Entry("an invalid share", "\\\\peaches\\a,b", windows.InvalidShareError, 11, -1, ','),
+	)
+
+	Context("when matching an error", func() {
+		It("should wrap the sentinel error", func() {
+			err := windows.Path("C:\\dir\\con").Errors()[0]
+
+			Expect(errors.Is(err, windows.ErrReservedName)).To(BeTrue())
+			Expect(errors.Is(err, windows.ErrReservedChar)).To(BeFalse())
+		})
+
+		It("should describe the location of the failure", func() {
+			err := windows.Path("C:\\a<b").Errors()[0]
+
+			Expect(err.Error()).To(Equal("path: a reserved character is present in component 0 at offset 4 ('<')"))
+		})
+
+		It("should name the error kind", func() {
+			Expect(windows.ReservedNameError.String()).To(Equal("reserved name"))
+		})
+	})
+
+	Context("when joining the errors", func() {
+		It("should be nil for a valid path", func() {
+			Expect(windows.Path("C:\\dir\\file").Err()).To(BeNil())
+		})
+
+		It("should match each error present", func() {
+			err := windows.Path("C:\\con\\file.").Err()
+
+			Expect(err).To(HaveOccurred())
+			Expect(errors.Is(err, windows.ErrReservedName)).To(BeTrue())
+			Expect(errors.Is(err, windows.ErrTrailingDotOrSpace)).To(BeTrue())
+		})
+
+		It("should be available through errors.As", func() {
+			var pathErr *windows.PathError
+
+			Expect(errors.As(windows.Path("C:\\dir\\file.").Err(), &pathErr)).To(BeTrue())
+			Expect(pathErr.Component).To(Equal(1))
+		})
+	})
+})
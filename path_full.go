@@ -0,0 +1,175 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package windows
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+)
+
+// ErrNoCurrentDirectory indicates a relative path could not be resolved, as
+// no current directory was available to resolve it against.
+var ErrNoCurrentDirectory = errors.New("path: no current directory available")
+
+// CurrentDirectories holds the state Windows consults when resolving a
+// relative path: the process current directory, along with the hidden
+// per-drive current directories stored in the ``=C:'' style environment
+// variables.
+type CurrentDirectories struct {
+	// Current is the process current directory; either a fully-qualified
+	// drive path or a UNC path.
+	Current string
+
+	// Drives maps a drive letter to the current directory of that drive.
+	Drives map[string]string
+}
+
+// ProcessDirectories returns the CurrentDirectories of the running process.
+// Values which are not fully-qualified Windows paths, such as the working
+// directory on a non-Windows machine, are ignored.
+func ProcessDirectories() *CurrentDirectories {
+	cd := &CurrentDirectories{Drives: map[string]string{}}
+
+	if wd, err := os.Getwd(); err == nil && Path(wd).isFullyQualified() {
+		cd.Current = wd
+	}
+	for _, kv := range os.Environ() {
+		// hidden per-drive variables take the form "=C:=C:\some\dir"
+		if len(kv) > 4 && kv[0] == '=' && kv[2] == ':' && kv[3] == '=' {
+			if c, err := isDriveLetter(rune(kv[1])); err == nil {
+				cd.Drives[string(c)] = kv[4:]
+			}
+		}
+	}
+
+	return cd
+}
+
+// current returns the parsed process current directory, if any.
+func (cd *CurrentDirectories) current() *PathImpl {
+	if cd == nil || len(cd.Current) == 0 {
+		return nil
+	}
+	if p := Path(cd.Current); p.isFullyQualified() {
+		return p
+	}
+	return nil
+}
+
+// drive returns the parsed current directory of the given drive. Just as
+// Windows does, the process current directory is preferred when it is on
+// the same drive, followed by the per-drive directory, and lastly the root
+// of the drive.
+func (cd *CurrentDirectories) drive(device string) *PathImpl {
+	if p := cd.current(); p != nil && strings.EqualFold(p.device, device) {
+		return p
+	}
+	if cd != nil {
+		for letter, dir := range cd.Drives {
+			if !strings.EqualFold(letter, device) {
+				continue
+			}
+			if p := Path(dir); p.isFullyQualified() && strings.EqualFold(p.device, device) {
+				return p
+			}
+		}
+	}
+	return Path(device + ":\\")
+}
+
+// isFullyQualified checks whether the Path does not depend upon any current
+// directory state to be resolved.
+func (p *PathImpl) isFullyQualified() bool {
+	return p.unc || p.unicode || p.devicePath || (len(p.device) > 0 && p.absolute)
+}
+
+// components returns all dirs, followed by the name when present.
+func (p *PathImpl) components() []string {
+	comps := make([]string, 0, len(p.dirs)+1)
+	comps = append(comps, p.dirs...)
+	if len(p.name) > 0 {
+		comps = append(comps, p.name)
+	}
+	return comps
+}
+
+// root returns the textual root of a fully-qualified Path.
+func (p *PathImpl) root() string {
+	if p.unc {
+		return p.uncRoot() + "\\"
+	}
+	return p.device + ":\\"
+}
+
+// FullPath resolves the Path to a fully-qualified location by purely
+// lexical processing, following the rules of GetFullPathName. Relative,
+// drive-relative (``C:foo'') and root-relative (``\foo'') paths are
+// resolved against the given CurrentDirectories, so the result is the
+// same regardless of the operating system in use.
+//
+// UNICODE paths are returned unchanged, as Windows does not normalize them.
+// Device namespace paths are only cleaned, as they have no current directory.
+//
+// See also MSDN, ``GetFullPathName function,''
+// https://msdn.microsoft.com/en-us/library/windows/desktop/aa364963(v=vs.85).aspx
+func (p *PathImpl) FullPath(cd *CurrentDirectories) (*PathImpl, error) {
+	if p.unicode {
+		return p, nil
+	}
+	if p.devicePath {
+		return p.Clean(), nil
+	}
+
+	var base *PathImpl
+	var comps []string
+	switch {
+	case p.isFullyQualified():
+		base = p
+		comps = p.components()
+	case len(p.device) > 0:
+		base = cd.drive(p.device)
+		comps = base.components()
+	default:
+		if base = cd.current(); base == nil {
+			return p, ErrNoCurrentDirectory
+		}
+		comps = base.components()
+		if p.absolute {
+			// root-relative, so only keep the root of the current directory
+			comps = nil
+		}
+	}
+	if base != p {
+		comps = append(comps, p.components()...)
+	}
+
+	comps = collapseDots(comps, true)
+	for i := range comps {
+		comps[i] = trimTrailing(comps[i], i == len(comps)-1 && len(p.name) > 0)
+	}
+
+	var full bytes.Buffer
+	full.WriteString(base.root())
+	full.WriteString(strings.Join(comps, "\\"))
+	if len(p.name) == 0 && len(p.dirs) > 0 && len(comps) > 0 {
+		full.WriteString("\\")
+	}
+
+	return Path(full.String()).withStreamOf(p), nil
+}
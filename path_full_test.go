@@ -0,0 +1,79 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package windows_test
+
+import (
+	"gitlab.com/jbenden/windows"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FullPath", func() {
+	cd := &windows.CurrentDirectories{
+		Current: "C:\\Users\\joe",
+		Drives:  map[string]string{"D": "D:\\work\\src"},
+	}
+
+	DescribeTable("when resolving against the current directories",
+		func(target string, expected string) {
+			subject, err := windows.Path(target).FullPath(cd)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(subject.ToString()).To(Equal(expected))
+		},
+		Entry("a relative path", "foo\\bar", "C:\\Users\\joe\\foo\\bar"),
+		Entry("a drive-relative path on the current drive", "c:foo\\bar", "C:\\Users\\joe\\foo\\bar"),
+		Entry("a drive-relative path on another drive", "D:foo", "D:\\work\\src\\foo"),
+		Entry("a bare drive on another drive", "D:", "D:\\work\\src"),
+		Entry("a drive-relative path on an unknown drive", "E:foo", "E:\\foo"),
+		Entry("a root-relative path", "\\foo", "C:\\foo"),
+		Entry("a relative path climbing above the root", "..\\..\\..\\x", "C:\\x"),
+		Entry("a fully-qualified path", "C:\\a\\.\\b\\..\\c", "C:\\a\\c"),
+		Entry("a UNC path", "\\\\peaches\\msys64\\..\\..\\x", "\\\\peaches\\msys64\\x"),
+		Entry("a stream", "C:\\a\\f.txt:s", "C:\\a\\f.txt:s"),
+		Entry("a relative stream", "f.txt:s:$DATA", "C:\\Users\\joe\\f.txt:s:$DATA"),
+	)
+
+	Context("when the current directory is a UNC share", func() {
+		It("should resolve a root-relative path to the share", func() {
+			subject, err := windows.Path("\\foo").FullPath(&windows.CurrentDirectories{Current: "\\\\peaches\\msys64\\home"})
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(subject.ToString()).To(Equal("\\\\peaches\\msys64\\foo"))
+		})
+	})
+
+	Context("when no current directory is known", func() {
+		It("should fail to resolve a relative path", func() {
+			subject, err := windows.Path("foo").FullPath(&windows.CurrentDirectories{})
+
+			Expect(err).Should(Equal(windows.ErrNoCurrentDirectory))
+			Expect(subject.Name()).To(Equal("foo"))
+		})
+	})
+
+	Context("when a UNICODE path is present", func() {
+		It("should be returned unchanged", func() {
+			subject := windows.Path("\\\\?\\C:\\a\\..\\b")
+			full, err := subject.FullPath(cd)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(full).To(BeIdenticalTo(subject))
+		})
+	})
+})
@@ -0,0 +1,107 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package windows
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// push appends a new final component, moving any current Name() into the
+// set of Dirs(). Any stream of the current Name() is dropped, as only the
+// final component may name a stream.
+func (p *PathImpl) push(comp string) {
+	if len(p.name) > 0 {
+		p.dirs = append(p.dirs, p.name)
+	}
+	p.name = comp
+	p.stream, p.streamType = "", ""
+}
+
+// validateComponent records an error for each problem with a file or
+// directory name given outside of the parsed text, as Path() would.
+func (p *PathImpl) validateComponent(comp string, component int) {
+	if p.IsPipe() {
+		// a pipe name may hold any character but a backslash
+		return
+	}
+	p.validateName(comp, -1, component)
+	if isReservedName(comp) {
+		p.addError(ErrReservedName, -1, component, 0)
+	}
+	p.checkComponentLength(comp, -1, component)
+	if hasTrailingDotOrSpace(comp) && !p.devicePath {
+		last, _ := utf8.DecodeLastRuneInString(comp)
+		p.addError(ErrTrailingDotOrSpace, -1, component, last)
+	}
+}
+
+// Join returns a new Path, with each of the given elements appended as a
+// child of the Path. Every element is a single file or directory name, and
+// is validated just as Path() does; any errors are collected into the
+// Errors() of the returned Path. Empty elements are ignored.
+func (p *PathImpl) Join(elem ...string) *PathImpl {
+	joined := p.clone()
+
+	for _, e := range elem {
+		if len(e) == 0 {
+			continue
+		}
+		joined.push(e)
+		joined.validateComponent(e, len(joined.dirs))
+	}
+
+	return joined
+}
+
+// JoinPath returns a new Path, composed of the other Path relative to this
+// one, following the rules Windows uses when combining paths:
+//	1. A fully-qualified, UNC or UNICODE other Path replaces this one
+//	2. A drive-relative other Path on a different drive replaces this one
+//	3. A root-relative other Path keeps only the drive or UNC share of this one
+//	4. Any other Path is appended to this one
+//
+// See also MSDN, ``PathCchCombineEx function,''
+// https://msdn.microsoft.com/en-us/library/windows/desktop/hh707086(v=vs.85).aspx
+func (p *PathImpl) JoinPath(other *PathImpl) *PathImpl {
+	switch {
+	case other.isFullyQualified():
+		return other.clone()
+	case len(other.device) > 0 && !strings.EqualFold(other.device, p.device):
+		return other.clone()
+	case other.absolute && len(p.device) == 0 && !p.unc && len(p.deviceName) == 0:
+		return other.clone()
+	}
+
+	joined := p.clone()
+	if other.absolute {
+		// root-relative, so only keep the root of this Path
+		joined.dirs, joined.name = nil, ""
+		joined.absolute = !p.unc
+	}
+	for _, comp := range other.components() {
+		joined.push(comp)
+	}
+	if len(other.name) == 0 && len(other.dirs) > 0 {
+		// the other Path refers to a directory
+		joined.push("")
+	}
+	joined.stream, joined.streamType = other.stream, other.streamType
+	joined.errs = append(joined.errs, other.errs...)
+
+	return joined
+}
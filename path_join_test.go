@@ -0,0 +1,83 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package windows_test
+
+import (
+	"gitlab.com/jbenden/windows"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Join", func() {
+	Context("when joining elements", func() {
+		It("should append each element", func() {
+			subject := windows.Path("C:\\msys64").Join("home", "", "joe")
+
+			Expect(subject.Dirs()).To(BeEquivalentTo([]string{"msys64", "home"}))
+			Expect(subject.Name()).To(Equal("joe"))
+			Expect(subject.Errors()).To(BeEmpty())
+			Expect(subject.ToUnicodeUNC()).To(Equal("\\\\?\\C:\\msys64\\home\\joe"))
+		})
+
+		It("should not modify the original path", func() {
+			subject := windows.Path("C:\\msys64")
+			subject.Join("home")
+
+			Expect(subject.Dirs()).To(BeEmpty())
+			Expect(subject.Name()).To(Equal("msys64"))
+		})
+
+		It("should validate each element", func() {
+			subject := windows.Path("C:\\msys64").Join("a|b", "c\\d")
+
+			Expect(subject.Errors()).To(HaveLen(2))
+			Expect(subject.Errors()).Should(ContainElement(WithTransform(ErrString, ContainSubstring("reserved character"))))
+		})
+
+		It("should validate a trailing dot or space", func() {
+			Expect(windows.Path("C:\\msys64").Join("foo. ").Errors()).To(ContainElement(MatchError(windows.ErrTrailingDotOrSpace)))
+		})
+	})
+
+	DescribeTable("when joining paths",
+		func(base string, other string, expected string) {
+			subject := windows.Path(base).JoinPath(windows.Path(other))
+
+			Expect(subject).ShouldNot(BeNil())
+			Expect(subject.ToUnicodeUNC()).To(Equal(expected))
+		},
+		Entry("a relative path", "C:\\msys64", "home\\joe", "\\\\?\\C:\\msys64\\home\\joe"),
+		Entry("a relative directory", "C:\\msys64", "home\\", "\\\\?\\C:\\msys64\\home\\"),
+		Entry("a fully-qualified path", "C:\\msys64", "D:\\x", "\\\\?\\D:\\x"),
+		Entry("a UNC path", "C:\\msys64", "\\\\peaches\\share\\x", "\\\\?\\UNC\\peaches\\share\\x"),
+		Entry("a drive-relative path on the same drive", "C:\\msys64", "c:x", "\\\\?\\C:\\msys64\\x"),
+		Entry("a drive-relative path on another drive", "C:\\msys64", "D:x", "\\\\?\\D:\\x"),
+		Entry("a root-relative path", "C:\\msys64\\home", "\\x", "\\\\?\\C:\\x"),
+		Entry("a root-relative path on a UNC share", "\\\\peaches\\share\\home", "\\x", "\\\\?\\UNC\\peaches\\share\\x"),
+	)
+
+	Context("when joining a root-relative path to a relative path", func() {
+		It("should replace the relative path", func() {
+			subject := windows.Path("a\\b").JoinPath(windows.Path("\\x"))
+
+			Expect(subject.IsAbsolute()).To(BeTrue())
+			Expect(subject.Dirs()).To(BeEmpty())
+			Expect(subject.Name()).To(Equal("x"))
+		})
+	})
+})
@@ -0,0 +1,89 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package windows
+
+// PathKind classifies a path just as RtlDetermineDosPathNameType_U does.
+type PathKind int
+
+// Possible kinds of a parsed path, in the order of RTL_PATH_TYPE
+const (
+	// UnknownPath is an empty path.
+	UnknownPath PathKind = iota
+	// UNCAbsolutePath is a UNC path; such as ``\\server\share\foo''.
+	UNCAbsolutePath
+	// DriveAbsolutePath is a fully-qualified drive path; such as ``C:\foo''.
+	DriveAbsolutePath
+	// DriveRelativePath is relative to the current directory of a drive;
+	// such as ``C:foo''.
+	DriveRelativePath
+	// RootedPath is relative to the root of the current drive; such as ``\foo''.
+	RootedPath
+	// RelativePath is relative to the current directory; such as ``foo''.
+	RelativePath
+	// LocalDevicePath is a Win32 device namespace or UNICODE path; such as
+	// ``\\.\COM1'' or ``\\?\C:\foo''.
+	LocalDevicePath
+	// RootLocalDevicePath is the root of the local devices; ``\\.'' or ``\\?''.
+	RootLocalDevicePath
+)
+
+var pathKindNames = [...]string{
+	"Unknown",
+	"UNCAbsolute",
+	"DriveAbsolute",
+	"DriveRelative",
+	"Rooted",
+	"Relative",
+	"LocalDevice",
+	"RootLocalDevice",
+}
+
+// String returns the name of the PathKind; or ``Unknown'' for a value
+// outside of those defined.
+func (k PathKind) String() string {
+	if k < 0 || int(k) >= len(pathKindNames) {
+		return "Unknown"
+	}
+	return pathKindNames[k]
+}
+
+// Kind returns the classification of the parsed Path. An NT object manager
+// path, such as ``\??\C:\foo'', is a RootedPath; as Windows classifies it.
+//
+// See also MSDN, ``Naming Files, Paths, and Namespaces,''
+// https://msdn.microsoft.com/en-us/library/windows/desktop/aa365247(v=vs.85).aspx
+func (p *PathImpl) Kind() PathKind {
+	switch {
+	case p.rootDevice:
+		return RootLocalDevicePath
+	case len(p.ntPrefix) > 0:
+		return RootedPath
+	case p.devicePath || p.unicode:
+		return LocalDevicePath
+	case p.unc:
+		return UNCAbsolutePath
+	case len(p.device) > 0 && p.absolute:
+		return DriveAbsolutePath
+	case len(p.device) > 0:
+		return DriveRelativePath
+	case p.absolute:
+		return RootedPath
+	case len(p.dirs) > 0 || len(p.name) > 0 || p.HasStream():
+		return RelativePath
+	}
+	return UnknownPath
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package windows_test
+
+import (
+	"gitlab.com/jbenden/windows"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Kind", func() {
+	DescribeTable("when classifying a path",
+		func(target string, kind windows.PathKind) {
+			subject := windows.Path(target)
+
+			Expect(subject).ShouldNot(BeNil())
+			Expect(subject.Kind()).To(Equal(kind))
+			Expect(subject.ToString()).To(Equal(target))
+			Expect(windows.Path(subject.ToString()).Kind()).To(Equal(kind))
+		},
+		Entry("an empty path", "", windows.UnknownPath),
+		Entry("a UNC path", "\\\\peaches\\msys64\\home", windows.UNCAbsolutePath),
+		Entry("a drive root", "C:\\", windows.DriveAbsolutePath),
+		Entry("a fully-qualified path", "C:\\foo\\bar", windows.DriveAbsolutePath),
+		Entry("a drive", "C:", windows.DriveRelativePath),
+		Entry("a drive-relative path", "C:foo\\bar", windows.DriveRelativePath),
+		Entry("a root", "\\", windows.RootedPath),
+		Entry("a root-relative path", "\\foo\\bar", windows.RootedPath),
+		Entry("a relative path", "foo\\bar", windows.RelativePath),
+		Entry("a relative directory", "foo\\bar\\", windows.RelativePath),
+		Entry("a relative stream", "foo:s", windows.RelativePath),
+		Entry("a Win32 device path", "\\\\.\\COM1", windows.LocalDevicePath),
+		Entry("a UNICODE drive path", "\\\\?\\C:\\foo", windows.LocalDevicePath),
+		Entry("a UNICODE volume", "\\\\?\\C:", windows.LocalDevicePath),
+		Entry("a UNICODE volume root", "\\\\?\\C:\\", windows.LocalDevicePath),
+		Entry("a UNICODE UNC path", "\\\\?\\UNC\\peaches\\msys64", windows.LocalDevicePath),
+		Entry("the Win32 device namespace root", "\\\\.", windows.RootLocalDevicePath),
+		Entry("the UNICODE namespace root", "\\\\?", windows.RootLocalDevicePath),
+		Entry("an NT path", "\\??\\C:\\foo", windows.RootedPath),
+		Entry("an NT volume", "\\??\\C:", windows.RootedPath),
+		Entry("an NT volume root", "\\??\\C:\\", windows.RootedPath),
+	)
+
+	Context("when a kind is printed", func() {
+		It("should have a name", func() {
+			Expect(windows.DriveRelativePath.String()).To(Equal("DriveRelative"))
+			Expect(windows.PathKind(42).String()).To(Equal("Unknown"))
+		})
+	})
+
+	Context("when a drive-relative path is joined to a root-relative path", func() {
+		It("should become a fully-qualified path", func() {
+			subject := windows.Path("C:foo").JoinPath(windows.Path("\\bar"))
+
+			Expect(subject.Kind()).To(Equal(windows.DriveAbsolutePath))
+			Expect(subject.ToString()).To(Equal("C:\\bar"))
+		})
+	})
+})
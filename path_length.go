@@ -0,0 +1,82 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package windows
+
+const (
+	// maxPath is MAX_PATH; the length of a path, including the terminating
+	// NULL, which is not UNICODE.
+	maxPath = 260
+	// maxUnicodePath is the length of a UNICODE path, including the
+	// terminating NULL.
+	maxUnicodePath = 32767
+	// maxComponent is the length of a single file or directory name.
+	maxComponent = 255
+)
+
+// utf16Len returns the number of UTF-16 code units needed to encode s, as
+// every length limit of Windows is counted in these.
+func utf16Len(s string) int {
+	n := 0
+	for _, c := range s {
+		n++
+		if c >= 0x10000 {
+			// encoded as a surrogate pair
+			n++
+		}
+	}
+	return n
+}
+
+// exceedsAt returns the rune offset within s of the first rune which takes
+// s beyond limit UTF-16 code units, or -1 when s fits.
+func exceedsAt(s string, limit int) int {
+	n, i := 0, 0
+	for _, c := range s {
+		n++
+		if c >= 0x10000 {
+			n++
+		}
+		if n > limit {
+			return i
+		}
+		i++
+	}
+	return -1
+}
+
+// checkLength records an error when the parsed text exceeds the maximum
+// length of a path; leaving room for the terminating NULL.
+func (p *PathImpl) checkLength(path string, longPathAware bool) {
+	limit := maxPath
+	if p.unicode || longPathAware {
+		limit = maxUnicodePath
+	}
+	if i := exceedsAt(path, limit-1); i >= 0 {
+		p.addError(ErrTooLong, i, -1, 0)
+	}
+}
+
+// checkComponentLength records an error when the given component, found at
+// the given rune offset, exceeds the maximum length of a file name.
+func (p *PathImpl) checkComponentLength(comp string, offset, component int) {
+	if i := exceedsAt(comp, maxComponent); i >= 0 {
+		if offset >= 0 {
+			offset += i
+		}
+		p.addError(ErrTooLong, offset, component, 0)
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package windows_test
+
+import (
+	"strings"
+
+	"gitlab.com/jbenden/windows"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Length limits", func() {
+	DescribeTable("when counting in UTF-16 code units",
+		func(target string, expected bool) {
+			subject := windows.Path(target)
+
+			if expected {
+				Expect(subject.Errors()).To(ContainElement(MatchError(windows.ErrTooLong)))
+			} else {
+				Expect(subject.Errors()).To(BeEmpty())
+			}
+		},
+		Entry("a component of accented letters, just under", strings.Repeat("\u00e9", 255), false),
+		Entry("a component of accented letters", strings.Repeat("\u00e9", 256), true),
+		Entry("a component of surrogate pairs, just under", strings.Repeat("\U0001F600", 127)+"a", false),
+		Entry("a component of surrogate pairs", strings.Repeat("\U0001F600", 128), true),
+		Entry("a path of accented letters, just under", "C:\\"+strings.Repeat(strings.Repeat("\u00e9", 127)+"\\", 2), false),
+		Entry("a path of accented letters", "C:\\"+strings.Repeat(strings.Repeat("\u00e9", 127)+"\\", 2)+"a", true),
+	)
+
+	Context("when a path is too long", func() {
+		It("should report where the limit is exceeded", func() {
+			subject := windows.Path("C:\\" + strings.Repeat(strings.Repeat("a", 99)+"\\", 3))
+
+			Expect(subject.Errors()).To(HaveLen(1))
+			Expect(subject.Errors()[0].(*windows.PathError).Offset).To(Equal(259))
+			Expect(subject.Errors()[0].(*windows.PathError).Component).To(Equal(-1))
+		})
+
+		It("should report where a component is too long", func() {
+			subject := windows.Path("C:\\" + strings.Repeat("a", 256))
+
+			Expect(subject.Errors()).To(HaveLen(1))
+			Expect(subject.Errors()[0].(*windows.PathError).Offset).To(Equal(258))
+			Expect(subject.Errors()[0].(*windows.PathError).Component).To(Equal(0))
+		})
+
+		It("should check the elements joined", func() {
+			Expect(windows.Path("C:\\dir").Join(strings.Repeat("a", 256)).Errors()).To(ContainElement(MatchError(windows.ErrTooLong)))
+		})
+	})
+
+	Context("when the application is long path aware", func() {
+		aware := windows.PathOptions{LongPathAware: true}
+
+		It("should lift the MAX_PATH limit", func() {
+			Expect(windows.ParseWith("C:\\"+strings.Repeat(strings.Repeat("a", 99)+"\\", 3), aware).Errors()).To(BeEmpty())
+		})
+
+		It("should keep the component limit", func() {
+			Expect(windows.ParseWith("C:\\"+strings.Repeat("a", 256), aware).Errors()).To(ContainElement(MatchError(windows.ErrTooLong)))
+		})
+	})
+})
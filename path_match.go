@@ -0,0 +1,155 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package windows
+
+import "unicode/utf16"
+
+// The DOS wildcards of FsRtlIsNameInExpression, which FindFirstFile
+// translates the wildcards of a pattern into.
+const (
+	// dosStar matches zero or more characters, until encountering and
+	// matching the final dot in the name.
+	dosStar = '<'
+	// dosQM matches any single character, or upon encountering a dot or the
+	// end of the name, advances past the contiguous dosQMs.
+	dosQM = '>'
+	// dosDot matches a dot, or zero characters at the end of the name.
+	dosDot = '"'
+)
+
+// translateWildcards translates the wildcards of a pattern just as
+// FindFirstFile does, before it is given to FsRtlIsNameInExpression:
+//	1. A ``?'' becomes DOS_QM
+//	2. A ``.'' followed by ``?'' or ``*'', or ending the pattern, becomes DOS_DOT
+//	3. A ``*'' followed by ``.'' becomes DOS_STAR
+func translateWildcards(pattern []uint16) []uint16 {
+	expr := make([]uint16, len(pattern))
+	for i, c := range pattern {
+		next := uint16(0)
+		if i+1 < len(pattern) {
+			next = pattern[i+1]
+		}
+		switch {
+		case c == '?':
+			c = dosQM
+		case c == '.' && (next == '?' || next == '*' || i+1 == len(pattern)):
+			c = dosDot
+		case c == '*' && next == '.':
+			c = dosStar
+		}
+		expr[i] = c
+	}
+	return expr
+}
+
+// Match reports whether the file or directory name matches the wildcard
+// pattern, just as FindFirstFile and ``dir'' do; rather than as
+// filepath.Match does. Names are compared ignoring case, as NTFS does, and
+// the pattern is translated into the DOS wildcards of
+// FsRtlIsNameInExpression; so:
+//	1. ``*.*'' matches names without an extension
+//	2. ``*.'' only matches names without an extension
+//	3. ``?'' consumes nothing at the end of a name, or before a dot; so
+//	   ``a?'' matches ``a'', and ``??.txt'' matches ``a.txt''
+//	4. ``file.?'' matches ``file''
+// The DOS wildcards ``<'', ``>'' and ``"'' may be used in the pattern
+// directly.
+//
+// See also MSDN, ``FsRtlIsNameInExpression routine,''
+// https://msdn.microsoft.com/en-us/library/windows/hardware/ff546850(v=vs.85).aspx
+func Match(pattern, name string) bool {
+	expr := translateWildcards(utf16.Encode([]rune(pattern)))
+	units := utf16.Encode([]rune(name))
+
+	lastDot := -1
+	for i, c := range units {
+		if c == '.' {
+			lastDot = i
+		}
+	}
+
+	// memo holds the result of matching expr[e:] to units[n:]; 0 when
+	// unknown, 1 when matching and 2 otherwise
+	memo := make([]byte, (len(expr)+1)*(len(units)+1))
+	var match func(e, n int) bool
+	match = func(e, n int) bool {
+		if e == len(expr) {
+			return n == len(units)
+		}
+		key := e*(len(units)+1) + n
+		if memo[key] != 0 {
+			return memo[key] == 1
+		}
+
+		var matched bool
+		switch c := expr[e]; c {
+		case '*':
+			matched = match(e+1, n) || (n < len(units) && match(e, n+1))
+		case dosStar:
+			matched = match(e+1, n) || (n < len(units) && n != lastDot && match(e, n+1))
+		case '?':
+			matched = n < len(units) && match(e+1, n+1)
+		case dosQM:
+			if n < len(units) && units[n] != '.' {
+				matched = match(e+1, n+1)
+			} else {
+				matched = match(e+1, n)
+			}
+		case dosDot:
+			if n < len(units) && units[n] == '.' {
+				matched = match(e+1, n+1)
+			} else {
+				matched = n == len(units) && match(e+1, n)
+			}
+		default:
+			matched = n < len(units) && upcase(rune(c)) == upcase(rune(units[n])) && match(e+1, n+1)
+		}
+
+		memo[key] = 2
+		if matched {
+			memo[key] = 1
+		}
+		return matched
+	}
+	return match(0, 0)
+}
+
+// MatchComponents reports whether each of the components, such as those
+// of Dirs() followed by Name(), matches the pattern at the same position,
+// as described by Match().
+func MatchComponents(patterns, components []string) bool {
+	if len(patterns) != len(components) {
+		return false
+	}
+	for i, pattern := range patterns {
+		if !Match(pattern, components[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Match reports whether the final component of the Path, being the Name()
+// or the last of the Dirs(), matches the wildcard pattern, as described by
+// the Match() function.
+func (p *PathImpl) Match(pattern string) bool {
+	name := p.name
+	if len(name) == 0 && len(p.dirs) > 0 {
+		name = p.dirs[len(p.dirs)-1]
+	}
+	return Match(pattern, name)
+}
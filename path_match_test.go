@@ -0,0 +1,71 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package windows_test
+
+import (
+	"gitlab.com/jbenden/windows"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Match", func() {
+	DescribeTable("when matching a name to a wildcard pattern",
+		func(pattern, name string, expected bool) {
+			Expect(windows.Match(pattern, name)).To(Equal(expected))
+		},
+		Entry("exact", "readme.txt", "readme.txt", true),
+		Entry("ignoring case", "README.TXT", "readme.txt", true),
+		Entry("ignoring case beyond ASCII", "ÄBC", "äbc", true),
+		Entry("different", "readme.txt", "readme.md", false),
+		Entry("star", "*", "readme.txt", true),
+		Entry("star matching nothing", "a*", "a", true),
+		Entry("extension", "*.log", "app.log", true),
+		Entry("extension ignoring case", "*.log", "APP.LOG", true),
+		Entry("extension of many dots", "*.log", "app.2017.log", true),
+		Entry("other extension", "*.log", "app.log.1", false),
+		Entry("star dot star", "*.*", "app.log", true),
+		Entry("star dot star without extension", "*.*", "README", true),
+		Entry("star dot without extension", "*.", "README", true),
+		Entry("star dot with extension", "*.", "app.log", false),
+		Entry("question mark", "a?c", "abc", true),
+		Entry("question mark at the end", "ab?", "ab", true),
+		Entry("question marks at the end", "a???", "a", true),
+		Entry("question mark in the middle", "a?c", "ac", false),
+		Entry("question marks before a dot", "??.txt", "a.txt", true),
+		Entry("question marks too few", "??.txt", "abc.txt", false),
+		Entry("dot question mark without extension", "file.?", "file", true),
+		Entry("dot question mark with extension", "file.?", "file.c", true),
+		Entry("prefix star", "app*.log", "application.log", true),
+		Entry("DOS star", "app<.log", "app.x.log", true),
+		Entry("DOS question mark", "a>", "a", true),
+		Entry("DOS dot", "app\"", "app", true),
+		Entry("empty pattern", "", "app", false),
+	)
+
+	It("matches all components", func() {
+		Expect(windows.MatchComponents([]string{"src", "*", "*.go"}, []string{"SRC", "windows", "path.go"})).To(BeTrue())
+		Expect(windows.MatchComponents([]string{"src", "*", "*.go"}, []string{"src", "path.go"})).To(BeFalse())
+		Expect(windows.MatchComponents([]string{"src", "*", "*.go"}, []string{"src", "windows", "path.c"})).To(BeFalse())
+	})
+
+	It("matches the name of a Path", func() {
+		Expect(windows.Path(`C:\logs\app.log`).Match("*.log")).To(BeTrue())
+		Expect(windows.Path(`C:\logs\app.log`).Match("*.txt")).To(BeFalse())
+		Expect(windows.Path(`C:\logs\`).Match("LOG?")).To(BeTrue())
+	})
+})
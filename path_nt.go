@@ -0,0 +1,202 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package windows
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrUnknownVolumeDevice indicates an NT volume device has no drive letter
+// in the given VolumeDevices.
+var ErrUnknownVolumeDevice = errors.New("path: no drive letter is known for the volume device")
+
+// ErrNotFullyQualified indicates a path must be fully-qualified, as it
+// cannot be resolved without a current directory.
+var ErrNotFullyQualified = errors.New("path: a fully-qualified path is required")
+
+const (
+	ntDosDevices    = "\\??\\"
+	ntGlobalDevices = "\\GLOBAL??\\"
+	ntDevice        = "\\Device\\"
+)
+
+// VolumeDevices maps the name of an NT volume device, such as
+// ``HarddiskVolume3'' or ``\Device\HarddiskVolume3'', to the drive letter
+// it is mounted on. It allows NT paths to be converted without querying the
+// running machine, such as when reading paths from a trace or log file.
+type VolumeDevices map[string]string
+
+// driveOf returns the drive letter of the named volume device.
+func (devs VolumeDevices) driveOf(deviceName string) (string, bool) {
+	for name, drive := range devs {
+		name = strings.TrimPrefix(name, ntDevice)
+		if strings.EqualFold(name, deviceName) && len(drive) > 0 {
+			if c, err := isDriveLetter(rune(drive[0])); err == nil {
+				return string(c), true
+			}
+		}
+	}
+	return "", false
+}
+
+// deviceOf returns the name of the volume device mounted on a drive letter.
+func (devs VolumeDevices) deviceOf(device string) (string, bool) {
+	for name := range devs {
+		if drive, ok := devs.driveOf(strings.TrimPrefix(name, ntDevice)); ok && drive == device {
+			return strings.TrimPrefix(name, ntDevice), true
+		}
+	}
+	return "", false
+}
+
+// ntNamespacePrefix returns the NT object manager prefix the path starts
+// with, if any, and whether the prefix is followed by a device name. The
+// ``\Device\'' prefix is only matched when devices is set, as it is also a
+// valid root-relative Win32 path.
+func ntNamespacePrefix(path string, devices bool) (string, bool) {
+	switch {
+	case strings.HasPrefix(path, ntDosDevices):
+		return path[:len(ntDosDevices)], false
+	case len(path) >= len(ntGlobalDevices) && strings.EqualFold(path[:len(ntGlobalDevices)], ntGlobalDevices):
+		return path[:len(ntGlobalDevices)], false
+	case devices && len(path) >= len(ntDevice) && strings.EqualFold(path[:len(ntDevice)], ntDevice):
+		return path[:len(ntDevice)], true
+	}
+	return "", false
+}
+
+// ParseNT parses a path just as Path() does, while also accepting NT
+// object manager paths rooted at a device; such as
+// ``\Device\HarddiskVolume3\Windows''. Path() parses these as a root-relative
+// Win32 path instead, as ``\Device'' is a valid directory name.
+func ParseNT(path string) *PathImpl {
+	return newPathImpl(path, PathOptions{NTDevices: true})
+}
+
+// isNTDevice checks whether the Path is rooted at an NT device object.
+func (p *PathImpl) isNTDevice() bool {
+	return strings.EqualFold(p.ntPrefix, ntDevice)
+}
+
+// IsNT checks whether the Path refers to the NT object manager namespace;
+// such as ``\??\C:\Windows'' or ``\Device\HarddiskVolume3\Windows''.
+func (p *PathImpl) IsNT() bool {
+	return len(p.ntPrefix) > 0
+}
+
+// NTNamespace returns the NT object manager prefix of the parsed Path; such
+// as ``\??\'', ``\GLOBAL??\'' or ``\Device\''.
+func (p *PathImpl) NTNamespace() string {
+	return p.ntPrefix
+}
+
+// joinComponents returns all components joined by backslashes, with a
+// trailing backslash when the Path refers to a directory.
+func (p *PathImpl) joinComponents() string {
+	joined := strings.Join(p.components(), "\\")
+	if len(p.name) == 0 && len(p.dirs) > 0 {
+		joined += "\\"
+	}
+	return joined
+}
+
+// NTPath returns the NT object manager form of a fully-qualified Path. A
+// drive mounted on one of the given VolumeDevices is rooted at that device,
+// such as ``\Device\HarddiskVolume3\Windows''; otherwise, the path is
+// rooted at ``\??\'', such as ``\??\C:\Windows''. The devs may be nil.
+//
+// Just as Windows does, a non-UNICODE Path is cleaned first.
+func (p *PathImpl) NTPath(devs VolumeDevices) (*PathImpl, error) {
+	if p.IsNT() {
+		return p.clone(), nil
+	}
+	if !p.isFullyQualified() {
+		return nil, ErrNotFullyQualified
+	}
+	if !p.unicode {
+		p = p.Clean()
+	}
+
+	if len(p.device) > 0 && !p.devicePath {
+		if name, ok := devs.deviceOf(p.device); ok {
+			return ParseNT(ntDevice + name + "\\" + p.joinComponents()).withStreamOf(p), nil
+		}
+	}
+
+	return Path(ntDosDevices + p.ToUnicodeUNC()[len("\\\\?\\"):]), nil
+}
+
+// win32Form returns the Path at the given Win32 root, such as ``C:\'',
+// followed by the components of the Path; or at the given UNICODE root,
+// such as ``\\?\C:\'', when the Win32 normalization would change any of
+// the components, as NT paths are never normalized.
+func (p *PathImpl) win32Form(root, unicodeRoot string) *PathImpl {
+	if p.hasWin32SafeComponents() {
+		return Path(root + p.joinComponents()).withStreamOf(p)
+	}
+	return Path(unicodeRoot + p.joinComponents()).withStreamOf(p)
+}
+
+// win32Drive returns the Path on the given drive; or the volume of the
+// drive itself, such as ``\\.\C:'', for an NT path naming the volume.
+func (p *PathImpl) win32Drive(drive string) *PathImpl {
+	if !p.absolute && len(p.dirs) == 0 && len(p.name) == 0 {
+		return Path("\\\\.\\" + drive + ":")
+	}
+	return p.win32Form(drive+":\\", "\\\\?\\"+drive+":\\")
+}
+
+// Win32Path returns the Win32 form of an NT object manager Path, such as
+// ``C:\Windows'' or ``\\server\share''. A volume device is converted to its
+// drive letter using the given VolumeDevices. Where the Win32 layer would
+// normalize a component, such as ``..'' or a trailing dot, the UNICODE form
+// is returned instead; such as ``\\?\C:\a\..''. A Path which is not an NT
+// path is returned as a copy.
+func (p *PathImpl) Win32Path(devs VolumeDevices) (*PathImpl, error) {
+	if !p.IsNT() {
+		return p.clone(), nil
+	}
+
+	if p.isNTDevice() {
+		switch {
+		case strings.EqualFold(p.deviceName, "Mup"), strings.EqualFold(p.deviceName, "LanmanRedirector"):
+			return p.win32Form("\\\\", "\\\\?\\UNC\\"), nil
+		case strings.EqualFold(p.deviceName, "NamedPipe"):
+			return p.win32Form("\\\\.\\pipe\\", "\\\\?\\pipe\\"), nil
+		case strings.EqualFold(p.deviceName, "Mailslot"):
+			return p.win32Form("\\\\.\\mailslot\\", "\\\\?\\mailslot\\"), nil
+		}
+		drive, ok := devs.driveOf(p.deviceName)
+		if !ok {
+			return nil, ErrUnknownVolumeDevice
+		}
+		return p.win32Drive(drive), nil
+	}
+
+	switch {
+	case p.volume != nil:
+		// a volume has no Win32 form beyond the UNICODE one
+		return Path(p.ToUnicodeUNC()), nil
+	case len(p.device) > 0:
+		return p.win32Drive(p.device), nil
+	case p.unc:
+		return p.win32Form(p.uncRoot()+"\\", "\\\\?\\UNC\\"+p.uncRoot()[2:]+"\\"), nil
+	default:
+		return p.win32Form("\\\\.\\", "\\\\?\\"), nil
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package windows_test
+
+import (
+	"gitlab.com/jbenden/windows"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NT", func() {
+	devs := windows.VolumeDevices{
+		"HarddiskVolume3":           "C",
+		"\\Device\\HarddiskVolume5": "D:",
+	}
+
+	DescribeTable("when an NT object manager path is present",
+		func(target string, namespace string, unicode string) {
+			subject := windows.ParseNT(target)
+
+			Expect(subject).ShouldNot(BeNil())
+			Expect(subject.Errors()).To(BeEmpty())
+			Expect(subject.IsNT()).To(BeTrue())
+			Expect(subject.NTNamespace()).To(Equal(namespace))
+			Expect(subject.ToString()).To(Equal(target))
+			Expect(subject.ToUnicodeUNC()).To(Equal(unicode))
+		},
+		Entry("a DOS device drive", "\\??\\C:\\Windows", "\\??\\", "\\\\?\\C:\\Windows"),
+		Entry("a DOS device UNC share", "\\??\\UNC\\peaches\\msys64\\x", "\\??\\", "\\\\?\\UNC\\peaches\\msys64\\x"),
+		Entry("a global DOS device drive", "\\GLOBAL??\\C:\\Windows", "\\GLOBAL??\\", "\\\\?\\C:\\Windows"),
+		Entry("a volume device", "\\Device\\HarddiskVolume3\\Windows", "\\Device\\", "\\\\?\\GLOBALROOT\\Device\\HarddiskVolume3\\Windows"),
+	)
+
+	Context("when a volume device path is present", func() {
+		It("should have the correct device name", func() {
+			subject := windows.ParseNT("\\Device\\HarddiskVolume3\\Windows\\System32")
+
+			Expect(subject.DeviceName()).To(Equal("HarddiskVolume3"))
+			Expect(subject.Dirs()).To(BeEquivalentTo([]string{"Windows"}))
+			Expect(subject.Name()).To(Equal("System32"))
+		})
+	})
+
+	Context("when a file on a volume device has a stream", func() {
+		It("should have the stream", func() {
+			subject := windows.ParseNT("\\Device\\HarddiskVolume3\\f.txt:Zone.Identifier")
+
+			Expect(subject.Errors()).To(BeEmpty())
+			Expect(subject.Name()).To(Equal("f.txt"))
+			Expect(subject.Stream()).To(Equal("Zone.Identifier"))
+			Expect(subject.ToString()).To(Equal("\\Device\\HarddiskVolume3\\f.txt:Zone.Identifier"))
+		})
+	})
+
+	DescribeTable("when a root-relative Win32 directory is named Device",
+		func(target string, expected string) {
+			subject := windows.Path(target)
+
+			Expect(subject.Errors()).To(BeEmpty())
+			Expect(subject.IsNT()).To(BeFalse())
+			Expect(subject.IsAbsolute()).To(BeTrue())
+			Expect(subject.Dirs()).To(HaveLen(1))
+			Expect(subject.Name()).To(Equal("foo"))
+			Expect(subject.ToString()).To(Equal(expected))
+			Expect(windows.Path(subject.ToString()).Dirs()).To(Equal(subject.Dirs()))
+		},
+		Entry("with backslashes", "\\Device\\foo", "\\Device\\foo"),
+		Entry("in lower case", "\\device\\foo", "\\device\\foo"),
+		Entry("with forward slashes", "/Device/foo", "\\Device\\foo"),
+	)
+
+	Context("when a lower case device path is parsed as NT", func() {
+		It("should be a device", func() {
+			subject := windows.ParseNT("\\device\\foo")
+
+			Expect(subject.IsNT()).To(BeTrue())
+			Expect(subject.DeviceName()).To(Equal("foo"))
+			Expect(subject.Dirs()).To(BeEmpty())
+		})
+	})
+
+	DescribeTable("when converting to the Win32 form",
+		func(target string, expected string) {
+			subject, err := windows.ParseNT(target).Win32Path(devs)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(subject.IsNT()).To(BeFalse())
+			Expect(subject.ToString()).To(Equal(expected))
+		},
+		Entry("a DOS device drive", "\\??\\C:\\Windows", "C:\\Windows"),
+		Entry("a DOS device UNC share", "\\??\\UNC\\peaches\\msys64\\x", "\\\\peaches\\msys64\\x"),
+		Entry("a DOS device named pipe", "\\??\\pipe\\myservice", "\\\\.\\pipe\\myservice"),
+		Entry("a DOS device volume", "\\??\\Volume{6a4b6b1e-3b1d-4f5c-9a3e-2f1d0c9b8a7d}\\x", "\\\\?\\Volume{6a4b6b1e-3b1d-4f5c-9a3e-2f1d0c9b8a7d}\\x"),
+		Entry("a DOS device volume root", "\\??\\Volume{6a4b6b1e-3b1d-4f5c-9a3e-2f1d0c9b8a7d}\\", "\\\\?\\Volume{6a4b6b1e-3b1d-4f5c-9a3e-2f1d0c9b8a7d}\\"),
+		Entry("a volume device", "\\Device\\HarddiskVolume3\\Windows", "C:\\Windows"),
+		Entry("a volume device with a qualified name", "\\Device\\HarddiskVolume5\\x", "D:\\x"),
+		Entry("a volume device itself", "\\Device\\HarddiskVolume3", "\\\\.\\C:"),
+		Entry("a volume device root", "\\Device\\HarddiskVolume3\\", "C:\\"),
+		Entry("a DOS device volume itself", "\\??\\C:", "\\\\.\\C:"),
+		Entry("a DOS device drive root", "\\??\\C:\\", "C:\\"),
+		Entry("a dot dot component", "\\??\\C:\\a\\..\\b", "\\\\?\\C:\\a\\..\\b"),
+		Entry("trailing dots and spaces", "\\??\\C:\\dir.\\file ", "\\\\?\\C:\\dir.\\file "),
+		Entry("a reserved name on a volume device", "\\Device\\HarddiskVolume3\\x\\con", "\\\\?\\C:\\x\\con"),
+		Entry("a stream", "\\??\\C:\\a\\f.txt:s", "C:\\a\\f.txt:s"),
+		Entry("a stream on a volume device", "\\Device\\HarddiskVolume3\\f.txt:s:$DATA", "C:\\f.txt:s:$DATA"),
+		Entry("a dot dot component of a UNC share", "\\??\\UNC\\peaches\\msys64\\..\\x", "\\\\?\\UNC\\peaches\\msys64\\..\\x"),
+		Entry("the multiple UNC provider", "\\Device\\Mup\\peaches\\msys64\\x", "\\\\peaches\\msys64\\x"),
+		Entry("the named pipe device", "\\Device\\NamedPipe\\myservice", "\\\\.\\pipe\\myservice"),
+		Entry("a Win32 path", "C:\\Windows", "C:\\Windows"),
+	)
+
+	Context("when a volume device is not known", func() {
+		It("should fail to convert to the Win32 form", func() {
+			_, err := windows.ParseNT("\\Device\\HarddiskVolume9\\Windows").Win32Path(devs)
+
+			Expect(err).Should(Equal(windows.ErrUnknownVolumeDevice))
+		})
+	})
+
+	DescribeTable("when converting to the NT form",
+		func(target string, volumes windows.VolumeDevices, expected string) {
+			subject, err := windows.Path(target).NTPath(volumes)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(subject.IsNT()).To(BeTrue())
+			Expect(subject.ToString()).To(Equal(expected))
+		},
+		Entry("a drive", "C:\\Windows\\..\\x", nil, "\\??\\C:\\x"),
+		Entry("a drive of a volume device", "c:\\Windows", devs, "\\Device\\HarddiskVolume3\\Windows"),
+		Entry("a stream on a volume device", "C:\\f.txt:s", devs, "\\Device\\HarddiskVolume3\\f.txt:s"),
+		Entry("a stream", "C:\\f.txt:s", nil, "\\??\\C:\\f.txt:s"),
+		Entry("a UNC share", "\\\\peaches\\msys64\\x", devs, "\\??\\UNC\\peaches\\msys64\\x"),
+		Entry("a named pipe", "\\\\.\\pipe\\myservice", nil, "\\??\\pipe\\myservice"),
+		Entry("a UNICODE drive", "\\\\?\\C:\\Windows\\..", nil, "\\??\\C:\\Windows\\.."),
+	)
+
+	Context("when a relative path is converted to the NT form", func() {
+		It("should fail", func() {
+			_, err := windows.Path("Windows").NTPath(nil)
+
+			Expect(err).Should(Equal(windows.ErrNotFullyQualified))
+		})
+	})
+})
@@ -0,0 +1,146 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package windows
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrNotRepresentable indicates a Path has no equivalent in another form,
+// such as a UNC share without an equivalent WSL path.
+var ErrNotRepresentable = errors.New("path: not representable in the requested form")
+
+// privateUseBase is the start of the private use range, into which
+// characters Windows does not permit in names are mapped by WSL and Cygwin.
+const privateUseBase = 0xf000
+
+// encodePrivateUse maps each character of a POSIX name which Windows does
+// not permit in names, into the U+F000 private use range.
+func encodePrivateUse(name string) string {
+	if strings.IndexFunc(name, func(c rune) bool { _, err := isPathNameLetter(c); return err != nil }) < 0 {
+		return name
+	}
+
+	var b strings.Builder
+	for _, c := range name {
+		if _, err := isPathNameLetter(c); err != nil {
+			c += privateUseBase
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// decodePrivateUse reverses encodePrivateUse(), restoring the characters
+// mapped into the U+F000 private use range.
+func decodePrivateUse(name string) string {
+	if strings.IndexFunc(name, isPrivateUse) < 0 {
+		return name
+	}
+
+	var b strings.Builder
+	for _, c := range name {
+		if isPrivateUse(c) {
+			c -= privateUseBase
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// isPrivateUse determines if the given rune is a character mapped by
+// encodePrivateUse(); other than a slash, which POSIX names never contain.
+func isPrivateUse(c rune) bool {
+	if c <= privateUseBase || c >= privateUseBase+0x80 || c == privateUseBase+'/' {
+		return false
+	}
+	_, err := isPathNameLetter(c - privateUseBase)
+	return err != nil
+}
+
+// splitPOSIX splits a POSIX path into its components, ignoring empty ones;
+// and reports whether the path ends with a slash.
+func splitPOSIX(path string) ([]string, bool) {
+	var comps []string
+	for _, comp := range strings.Split(path, "/") {
+		if len(comp) > 0 {
+			comps = append(comps, comp)
+		}
+	}
+	return comps, len(comps) > 0 && strings.HasSuffix(path, "/")
+}
+
+// posixDrive returns the drive letter, along with the remaining path, of a
+// POSIX path with a drive mounted under the given prefix; such as
+// ``/mnt/c/src'' for the prefix ``/mnt/''.
+func posixDrive(path, prefix string) (string, string, bool) {
+	if !strings.HasPrefix(path, prefix) {
+		return "", "", false
+	}
+	rest := path[len(prefix):]
+	if len(rest) == 0 || (len(rest) > 1 && rest[1] != '/') {
+		return "", "", false
+	}
+	c, err := isDriveLetter(rune(rest[0]))
+	if err != nil {
+		return "", "", false
+	}
+	return driveLetter(c), rest[1:], true
+}
+
+// hasSlash determines if any name of the Path holds a forward slash; which
+// only a UNICODE path may, as a literal character without a POSIX
+// equivalent.
+func (p *PathImpl) hasSlash() bool {
+	if strings.ContainsRune(p.node, '/') || strings.ContainsRune(p.share, '/') {
+		return true
+	}
+	for _, comp := range p.components() {
+		if strings.ContainsRune(comp, '/') {
+			return true
+		}
+	}
+	return false
+}
+
+// joinPOSIX joins the components of a Path with forward slashes, decoding
+// each component.
+func (p *PathImpl) joinPOSIX(decode func(string) string) string {
+	comps := p.components()
+	for i, comp := range comps {
+		comps[i] = decode(comp)
+	}
+	joined := strings.Join(comps, "/")
+	if len(p.name) == 0 && len(p.dirs) > 0 {
+		joined += "/"
+	}
+	return joined
+}
+
+// fromPOSIX parses the given Windows root followed by the POSIX components,
+// encoding each component.
+func fromPOSIX(root string, comps []string, dir bool, encode func(string) string) *PathImpl {
+	for i, comp := range comps {
+		comps[i] = encode(comp)
+	}
+	path := root + strings.Join(comps, "\\")
+	if dir {
+		path += "\\"
+	}
+	return Path(path)
+}
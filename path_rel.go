@@ -0,0 +1,113 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package windows
+
+import "errors"
+
+// ErrNotRelative indicates the target of Rel() cannot be expressed relative
+// to the base, as the base climbs above its unknown current directory.
+var ErrNotRelative = errors.New("path: unable to make target relative to base")
+
+// RootMismatchError is returned by Rel() when the base and target paths are
+// on different drives or UNC shares, and so have no relative path between
+// them.
+type RootMismatchError struct {
+	Base   string
+	Target string
+}
+
+// Error returns a description of the mismatched roots.
+func (e *RootMismatchError) Error() string {
+	return "path: \"" + e.Base + "\" and \"" + e.Target + "\" do not share a common root"
+}
+
+// relRoot returns the textual root of a cleaned Path.
+func (p *PathImpl) relRoot() string {
+	switch {
+	case p.devicePath:
+		return "\\\\.\\" + p.deviceName
+	case p.isNTDevice():
+		return p.ntPrefix + p.deviceName
+	case p.volume != nil:
+		return "\\\\?\\Volume" + p.volume.String()
+	case p.unc:
+		return p.uncRoot()
+	case len(p.device) > 0 && p.absolute:
+		return p.device + ":\\"
+	case len(p.device) > 0:
+		return p.device + ":"
+	case p.absolute:
+		return "\\"
+	default:
+		return ""
+	}
+}
+
+// Rel returns a relative Path that, when joined to base with JoinPath(),
+// refers to the same location as target. Both paths are cleaned first, and
+// their components are compared case-insensitively, as Windows does.
+//
+// A *RootMismatchError is returned when the paths are on different drives
+// or UNC shares, or when only one of them is absolute. The stream of the
+// target is kept; so ErrNotRelative is returned for a stream of the base
+// itself, or of one of its parents.
+func Rel(base, target *PathImpl) (*PathImpl, error) {
+	base, target = base.Clean(), target.Clean()
+
+	baseRoot := base.relRoot()
+	targetRoot := target.relRoot()
+	if !equalUpcase(baseRoot, targetRoot) {
+		return nil, &RootMismatchError{Base: baseRoot, Target: targetRoot}
+	}
+
+	baseComps := base.components()
+	targetComps := target.components()
+	if len(baseComps) == 1 && baseComps[0] == "." {
+		baseComps = nil
+	}
+	if len(targetComps) == 1 && targetComps[0] == "." {
+		targetComps = nil
+	}
+
+	common := 0
+	for common < len(baseComps) && common < len(targetComps) && equalUpcase(baseComps[common], targetComps[common]) {
+		common++
+	}
+
+	rel := &PathImpl{}
+	for _, comp := range baseComps[common:] {
+		if comp == ".." {
+			return nil, ErrNotRelative
+		}
+		rel.push("..")
+	}
+	for _, comp := range targetComps[common:] {
+		rel.push(comp)
+	}
+	if len(rel.name) == 0 {
+		rel.name = "."
+	}
+	if target.HasStream() {
+		if len(targetComps) == common {
+			// only the file itself may name its stream
+			return nil, ErrNotRelative
+		}
+		rel.withStreamOf(target)
+	}
+
+	return rel, nil
+}
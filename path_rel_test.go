@@ -0,0 +1,79 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package windows_test
+
+import (
+	"gitlab.com/jbenden/windows"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Rel", func() {
+	DescribeTable("when computing a relative path",
+		func(base string, target string, dirs []string, name string) {
+			subject, err := windows.Rel(windows.Path(base), windows.Path(target))
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(subject.IsRelative()).To(BeTrue())
+			Expect(subject.Dirs()).To(BeEquivalentTo(dirs))
+			Expect(subject.Name()).To(Equal(name))
+		},
+		Entry("sibling directories", "C:\\a\\b\\c", "c:\\A\\x\\y", []string{"..", "..", "x"}, "y"),
+		Entry("the same directory", "C:\\a\\b", "C:\\A\\B\\", []string(nil), "."),
+		Entry("a child of the drive root", "C:\\", "C:\\a\\b", []string{"a"}, "b"),
+		Entry("a UNC share", "\\\\peaches\\msys64\\home", "\\\\PEACHES\\MSYS64\\tmp", []string{".."}, "tmp"),
+		Entry("relative paths", "a\\b", "c", []string{"..", ".."}, "c"),
+		Entry("unclean paths", "C:\\a\\.\\b\\..", "C:\\a\\b\\..\\c", []string(nil), "c"),
+	)
+
+	DescribeTable("when the paths do not share a root",
+		func(base string, target string) {
+			_, err := windows.Rel(windows.Path(base), windows.Path(target))
+
+			Expect(err).Should(HaveOccurred())
+			Expect(err).To(BeAssignableToTypeOf(&windows.RootMismatchError{}))
+		},
+		Entry("different drives", "C:\\a", "D:\\a"),
+		Entry("different UNC shares", "\\\\peaches\\a\\x", "\\\\peaches\\b\\x"),
+		Entry("different UNC nodes", "\\\\peaches\\a\\x", "\\\\pears\\a\\x"),
+		Entry("an absolute and a relative path", "C:\\a", "a"),
+	)
+
+	Context("when the target is a stream", func() {
+		It("should keep the stream", func() {
+			subject, err := windows.Rel(windows.Path("C:\\a"), windows.Path("C:\\a\\b\\f.txt:s"))
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(subject.ToString()).To(Equal("b\\f.txt:s"))
+		})
+
+		It("should fail for a stream of the base", func() {
+			_, err := windows.Rel(windows.Path("C:\\a\\f.txt"), windows.Path("C:\\a\\f.txt:s"))
+
+			Expect(err).Should(Equal(windows.ErrNotRelative))
+		})
+	})
+
+	Context("when the base climbs above its current directory", func() {
+		It("should fail", func() {
+			_, err := windows.Rel(windows.Path("..\\a"), windows.Path("b"))
+
+			Expect(err).Should(Equal(windows.ErrNotRelative))
+		})
+	})
+})
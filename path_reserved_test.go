@@ -0,0 +1,72 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package windows_test
+
+import (
+	"gitlab.com/jbenden/windows"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Reserved names", func() {
+	DescribeTable("when a reserved DOS device name is present",
+		func(target string, count int) {
+			subject := windows.Path(target)
+
+			Expect(subject).ShouldNot(BeNil())
+			Expect(subject.Errors()).To(HaveLen(count))
+			Expect(subject.Errors()).To(ContainElement(MatchError(windows.ErrReservedName)))
+		},
+		Entry("a console", "C:\\CON", 1),
+		Entry("a lower-case printer", "C:\\prn", 1),
+		Entry("an auxiliary device", "aux", 1),
+		Entry("a null device with an extension", "C:\\nul.txt", 1),
+		Entry("a console with spaces before the extension", "C:\\con .log", 1),
+		Entry("a serial port", "C:\\COM1\\x", 1),
+		Entry("a parallel port", "C:\\lpt9.tar.gz", 1),
+		Entry("a superscript serial port", "C:\\COM\u00b9", 1),
+		Entry("a superscript parallel port", "C:\\LPT\u00b3.txt", 1),
+		Entry("multiple components", "C:\\aux\\nul", 2),
+		Entry("a UNICODE path", "\\\\?\\C:\\nul", 1),
+	)
+
+	DescribeTable("when a name resembling a DOS device is present",
+		func(target string) {
+			subject := windows.Path(target)
+
+			Expect(subject).ShouldNot(BeNil())
+			Expect(subject.Errors()).To(BeEmpty())
+		},
+		Entry("a longer name", "C:\\console"),
+		Entry("a name with a prefix", "C:\\xnul.txt"),
+		Entry("a serial port without a number", "C:\\COM"),
+		Entry("a serial port zero", "C:\\COM0"),
+		Entry("a serial port with two digits", "C:\\COM10"),
+		Entry("a device name as an extension", "C:\\file.con"),
+		Entry("a Win32 device", "\\\\.\\COM1"),
+		Entry("a named pipe", "\\\\.\\pipe\\con"),
+	)
+
+	Context("when joining a reserved DOS device name", func() {
+		It("should have errors present", func() {
+			subject := windows.Path("C:\\msys64").Join("nul")
+
+			Expect(subject.Errors()).To(ContainElement(MatchError(windows.ErrReservedName)))
+		})
+	})
+})
@@ -0,0 +1,98 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package windows
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SanitizeOptions controls how SanitizeName() fixes a name.
+type SanitizeOptions struct {
+	// Replacement replaces each reserved or control character. When it is
+	// not valid at the end of a name, an underscore is used instead.
+	Replacement rune
+	// Escape percent-encodes each reserved or control character, along with
+	// the percent sign itself, instead of replacing it; so ``a<b'' becomes
+	// ``a%3Cb''.
+	Escape bool
+	// MaxLength is the maximum length of the name, in UTF-16 code units. It
+	// is limited to, and defaults to, the 255 permitted by Windows.
+	MaxLength int
+}
+
+// SanitizeName returns a valid Windows file or directory name, made from
+// arbitrary text; such as a report title or an email subject. The result
+// always passes Path() validation without errors, as:
+//	1. Reserved and control characters are replaced or escaped
+//	2. Trailing dots and spaces are removed
+//	3. The name is truncated to the MaxLength, keeping any extension and
+//	   never splitting a surrogate pair
+//	4. A reserved DOS device name, such as ``CON'', is prefixed by an
+//	   underscore
+//	5. An empty name becomes an underscore
+func SanitizeName(s string, opts SanitizeOptions) string {
+	replacement := opts.Replacement
+	if _, err := isPathNameLetter(replacement); err != nil || replacement == '.' || replacement == ' ' {
+		replacement = '_'
+	}
+	max := opts.MaxLength
+	if max <= 0 || max > maxComponent {
+		max = maxComponent
+	}
+
+	var b strings.Builder
+	for _, c := range s {
+		_, err := isPathNameLetter(c)
+		switch {
+		case opts.Escape && (err != nil || c == '%'):
+			fmt.Fprintf(&b, "%%%02X", c)
+		case err != nil:
+			b.WriteRune(replacement)
+		default:
+			b.WriteRune(c)
+		}
+	}
+
+	name := strings.TrimRight(b.String(), ". ")
+	for {
+		name = strings.TrimRight(truncateName(name, max), ". ")
+		switch {
+		case len(name) == 0:
+			name = "_"
+		case isReservedName(name):
+			name = "_" + name
+			continue
+		}
+		return name
+	}
+}
+
+// truncateName shortens a name to max UTF-16 code units, without splitting
+// a surrogate pair. An extension shorter than half of max is kept.
+func truncateName(name string, max int) string {
+	if exceedsAt(name, max) < 0 {
+		return name
+	}
+
+	ext := ""
+	if i := strings.LastIndexByte(name, '.'); i > 0 && utf16Len(name[i:]) < max/2 {
+		name, ext = name[:i], name[i:]
+	}
+	runes := []rune(name)
+	return string(runes[:exceedsAt(name, max-utf16Len(ext))]) + ext
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package windows_test
+
+import (
+	"strings"
+	"unicode/utf16"
+
+	"gitlab.com/jbenden/windows"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SanitizeName", func() {
+	DescribeTable("when sanitizing a name",
+		func(target string, opts windows.SanitizeOptions, expected string) {
+			Expect(windows.SanitizeName(target, opts)).To(Equal(expected))
+		},
+		Entry("a valid name", "report.pdf", windows.SanitizeOptions{}, "report.pdf"),
+		Entry("reserved characters", "Q1: <draft>?.pdf", windows.SanitizeOptions{}, "Q1_ _draft__.pdf"),
+		Entry("control characters", "a\tb\x00c", windows.SanitizeOptions{}, "a_b_c"),
+		Entry("separators", "a/b\\c", windows.SanitizeOptions{}, "a_b_c"),
+		Entry("a replacement", "a:b", windows.SanitizeOptions{Replacement: '-'}, "a-b"),
+		Entry("an invalid replacement", "a:b", windows.SanitizeOptions{Replacement: '*'}, "a_b"),
+		Entry("escaped characters", "50% <off>", windows.SanitizeOptions{Escape: true}, "50%25 %3Coff%3E"),
+		Entry("trailing dots and spaces", "Re: hello. . ", windows.SanitizeOptions{}, "Re_ hello"),
+		Entry("a reserved device name", "con", windows.SanitizeOptions{}, "_con"),
+		Entry("a reserved device name with an extension", "NUL.txt", windows.SanitizeOptions{}, "_NUL.txt"),
+		Entry("a reserved device name after trimming", "COM1. ", windows.SanitizeOptions{}, "_COM1"),
+		Entry("an empty name", "", windows.SanitizeOptions{}, "_"),
+		Entry("only dots", "..", windows.SanitizeOptions{}, "_"),
+		Entry("a truncated name", "abcdefgh", windows.SanitizeOptions{MaxLength: 4}, "abcd"),
+		Entry("a truncated name keeping its extension", "abcdefgh.txt", windows.SanitizeOptions{MaxLength: 10}, "abcdef.txt"),
+		Entry("a truncated name ending with a space", "abc defgh", windows.SanitizeOptions{MaxLength: 4}, "abc"),
+		Entry("a truncated surrogate pair", "ab\U0001F600", windows.SanitizeOptions{MaxLength: 3}, "ab"),
+		Entry("a truncated reserved device name", "console", windows.SanitizeOptions{MaxLength: 3}, "_co"),
+	)
+
+	DescribeTable("when the result is parsed",
+		func(target string) {
+			name := windows.SanitizeName(target, windows.SanitizeOptions{})
+			subject := windows.Path(name)
+
+			Expect(subject.Errors()).To(BeEmpty())
+			Expect(subject.Dirs()).To(BeEmpty())
+			Expect(subject.Name()).To(Equal(name))
+			Expect(len(utf16.Encode([]rune(name)))).To(BeNumerically("<=", 255))
+		},
+		Entry("an email subject", "RE: FW: Invoice #42 <urgent> | \"final\"?"),
+		Entry("a drive", "C:"),
+		Entry("a UNC path", "\\\\server\\share"),
+		Entry("a UNICODE path", "\\\\?\\C:\\file"),
+		Entry("a stream", "file:stream:$DATA"),
+		Entry("a device path", "\\\\.\\COM1"),
+		Entry("a long name", strings.Repeat("a", 300)+".txt"),
+		Entry("a long name of surrogate pairs", strings.Repeat("\U0001F600", 200)),
+		Entry("invalid UTF-8", "a\xff\xfeb"),
+		Entry("spaces", "   "),
+		Entry("a superscript device name", "LPT\u00b2.log"),
+	)
+})
@@ -0,0 +1,60 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package windows
+
+import "strings"
+
+// SeparatorStyle describes which path separators were used by a parsed path.
+type SeparatorStyle int
+
+// Possible separator styles of a parsed path
+const (
+	BackslashSeparators SeparatorStyle = iota
+	SlashSeparators
+	MixedSeparators
+)
+
+// isSeparator determines if the given rune separates path components. The
+// Win32 layer accepts a forward slash as well as a backslash, except within
+// UNICODE and NT paths; where a forward slash is an ordinary character.
+func (p *PathImpl) isSeparator(c rune) bool {
+	return c == '\\' || (c == '/' && !p.unicode)
+}
+
+// Separators returns the style of path separators used by the parsed Path.
+func (p *PathImpl) Separators() SeparatorStyle {
+	return p.separators
+}
+
+// ToSlash returns the same representation as ToString(), but using forward
+// slashes as separators. UNICODE and NT paths require backslashes, so they
+// are returned just as ToString() does.
+func (p *PathImpl) ToSlash() string {
+	if p.unicode {
+		return p.ToString()
+	}
+	return strings.Replace(p.ToString(), "\\", "/", -1)
+}
+
+// ToParsedStyle returns the representation of either ToSlash(), when the
+// parsed Path used only forward slashes, or ToString() otherwise.
+func (p *PathImpl) ToParsedStyle() string {
+	if p.separators == SlashSeparators {
+		return p.ToSlash()
+	}
+	return p.ToString()
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package windows_test
+
+import (
+	"gitlab.com/jbenden/windows"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Separators", func() {
+	DescribeTable("when forward slashes are present",
+		func(target string, style windows.SeparatorStyle, expected string) {
+			subject := windows.Path(target)
+
+			Expect(subject).ShouldNot(BeNil())
+			Expect(subject.Errors()).To(BeEmpty())
+			Expect(subject.Separators()).To(Equal(style))
+			Expect(subject.ToString()).To(Equal(expected))
+		},
+		Entry("a drive path", "C:/Users/me/file.txt", windows.SlashSeparators, "C:\\Users\\me\\file.txt"),
+		Entry("a UNC path", "//server/share/x", windows.SlashSeparators, "\\\\server\\share\\x"),
+		Entry("a mixed drive path", "C:\\Users/me\\file.txt", windows.MixedSeparators, "C:\\Users\\me\\file.txt"),
+		Entry("a mixed UNC path", "\\/server\\share/x", windows.MixedSeparators, "\\\\server\\share\\x"),
+		Entry("a device path", "//./pipe/myservice", windows.SlashSeparators, "\\\\.\\pipe\\myservice"),
+		Entry("a backslash path", "C:\\Users\\me", windows.BackslashSeparators, "C:\\Users\\me"),
+	)
+
+	Context("when a drive path with forward slashes is present", func() {
+		var subject *windows.PathImpl
+
+		BeforeEach(func() {
+			subject = windows.Path("C:/Users/me/file.txt")
+		})
+
+		It("should have the correct disk/device present", func() {
+			Expect(subject.Device()).To(Equal("C"))
+		})
+
+		It("should be an absolute path", func() {
+			Expect(subject.IsAbsolute()).To(BeTrue())
+		})
+
+		It("should have the correct paths present", func() {
+			Expect(subject.Dirs()).To(BeEquivalentTo([]string{"Users", "me"}))
+			Expect(subject.Name()).To(Equal("file.txt"))
+		})
+
+		It("should render in the parsed style", func() {
+			Expect(subject.ToSlash()).To(Equal("C:/Users/me/file.txt"))
+			Expect(subject.ToParsedStyle()).To(Equal("C:/Users/me/file.txt"))
+		})
+	})
+
+	Context("when a UNICODE path with forward slashes is present", func() {
+		var subject *windows.PathImpl
+
+		BeforeEach(func() {
+			subject = windows.Path("\\\\?\\C:\\Users/me")
+		})
+
+		It("should not treat a forward slash as a separator", func() {
+			Expect(subject.Name()).To(Equal("Users/me"))
+			Expect(subject.Errors()).Should(ContainElement(WithTransform(ErrString, ContainSubstring("reserved character"))))
+		})
+
+		It("should use backslashes", func() {
+			Expect(subject.Separators()).To(Equal(windows.BackslashSeparators))
+			Expect(subject.ToSlash()).To(Equal(subject.ToString()))
+		})
+	})
+
+	Context("when a UNICODE prefix uses forward slashes", func() {
+		It("should be a device path", func() {
+			subject := windows.Path("//?/C:/Users")
+
+			Expect(subject.IsDevice()).To(BeTrue())
+			Expect(subject.Device()).To(Equal("C"))
+			Expect(subject.Name()).To(Equal("Users"))
+		})
+	})
+})
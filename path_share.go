@@ -0,0 +1,75 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package windows
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidShare indicates a UNC share name is too long or contains a
+// character which is not permitted in share names.
+var ErrInvalidShare = errors.New("path: invalid share name specified")
+
+// maxShareName is the maximum length of a share name; NNLEN.
+const maxShareName = 80
+
+// setShare records the share of a UNC path, found at the given rune offset,
+// validating its name.
+func (p *PathImpl) setShare(name string, offset int) {
+	p.share = name
+	if utf8.RuneCountInString(name) > maxShareName {
+		p.addError(ErrInvalidShare, offset+maxShareName, -1, 0)
+		return
+	}
+	for _, c := range name {
+		if c < 32 || strings.ContainsRune("\"/\\[]:|<>+=;,?*", c) {
+			p.addError(ErrInvalidShare, offset, -1, c)
+			return
+		}
+		offset++
+	}
+}
+
+// uncRoot returns the ``\\server\share'' root of a UNC path, without a
+// trailing backslash.
+func (p *PathImpl) uncRoot() string {
+	if len(p.share) == 0 {
+		return "\\\\" + p.node
+	}
+	return "\\\\" + p.node + "\\" + p.share
+}
+
+// Share returns the share name of a UNC path, or an empty string.
+func (p *PathImpl) Share() string {
+	return p.share
+}
+
+// IsAdminShare determines if the Path refers to one of the administrative
+// shares created by Windows; such as ``C$'', ``ADMIN$'', or ``IPC$''.
+func (p *PathImpl) IsAdminShare() bool {
+	share := strings.ToUpper(p.share)
+	switch {
+	case share == "ADMIN$" || share == "IPC$":
+		return true
+	case len(share) == 2 && share[1] == '$':
+		_, err := isDriveLetter(rune(share[0]))
+		return err == nil
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package windows_test
+
+import (
+	"strings"
+
+	"gitlab.com/jbenden/windows"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Share", func() {
+	DescribeTable("when a UNC share is present",
+		func(target, node, share string, dirs []string, name string) {
+			subject := windows.Path(target)
+
+			Expect(subject).ShouldNot(BeNil())
+			Expect(subject.Errors()).To(BeEmpty())
+			Expect(subject.Node()).To(Equal(node))
+			Expect(subject.Share()).To(Equal(share))
+			Expect(subject.Dirs()).To(BeEquivalentTo(dirs))
+			Expect(subject.Name()).To(Equal(name))
+		},
+		Entry("a node alone", "\\\\peaches", "peaches", "", []string(nil), ""),
+		Entry("a share", "\\\\peaches\\msys64", "peaches", "msys64", []string(nil), ""),
+		Entry("a share with a trailing backslash", "\\\\peaches\\msys64\\", "peaches", "msys64", []string(nil), ""),
+		Entry("a file on a share", "\\\\peaches\\msys64\\home\\joe", "peaches", "msys64", []string{"home"}, "joe"),
+		Entry("a UNICODE UNC share", "\\\\?\\UNC\\peaches\\msys64", "peaches", "msys64", []string(nil), ""),
+		Entry("a file on a UNICODE UNC share", "\\\\?\\UNC\\peaches\\msys64\\home", "peaches", "msys64", []string(nil), "home"),
+	)
+
+	DescribeTable("when an administrative share is present",
+		func(target string, admin bool) {
+			Expect(windows.Path(target).IsAdminShare()).To(Equal(admin))
+		},
+		Entry("a drive share", "\\\\peaches\\C$\\Windows", true),
+		Entry("a lower case drive share", "\\\\peaches\\d$", true),
+		Entry("the admin share", "\\\\peaches\\ADMIN$", true),
+		Entry("the IPC share", "\\\\?\\UNC\\peaches\\IPC$", true),
+		Entry("a regular share", "\\\\peaches\\msys64", false),
+		Entry("a hidden share", "\\\\peaches\\secret$", false),
+		Entry("a local path", "C:\\Windows", false),
+	)
+
+	DescribeTable("when an invalid share is present",
+		func(target string) {
+			Expect(windows.Path(target).Errors()).To(ContainElement(MatchError(windows.ErrInvalidShare)))
+		},
+		Entry("a share with a bracket", "\\\\peaches\\ms[64]"),
+		Entry("a share with a plus", "\\\\peaches\\a+b\\file"),
+		Entry("a share with an equals", "\\\\peaches\\a=b"),
+		Entry("a share with a control character", "\\\\peaches\\a\x01b"),
+		Entry("a share too long", "\\\\peaches\\"+strings.Repeat("s", 81)),
+	)
+
+	Context("when generating a UNC path", func() {
+		It("should keep the share as part of the root", func() {
+			subject := windows.Path("\\\\peaches\\msys64\\..\\..\\home").Clean()
+
+			Expect(subject.ToString()).To(Equal("\\\\peaches\\msys64\\home"))
+		})
+
+		It("should be a correct UNICODE UNC path generated", func() {
+			Expect(windows.Path("\\\\peaches\\msys64\\").ToUnicodeUNC()).To(Equal("\\\\?\\UNC\\peaches\\msys64"))
+		})
+
+		It("should join root-relative paths to the share", func() {
+			subject := windows.Path("\\\\peaches\\msys64\\home").JoinPath(windows.Path("\\etc"))
+
+			Expect(subject.ToString()).To(Equal("\\\\peaches\\msys64\\etc"))
+		})
+	})
+})
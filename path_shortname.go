@@ -0,0 +1,220 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package windows
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+)
+
+// ErrInvalidShortName indicates an alias given to WithShortName() is not a
+// legal 8.3 name.
+var ErrInvalidShortName = errors.New("path: not a legal 8.3 short name")
+
+// shortNameChars are the punctuation characters permitted within an 8.3
+// name, beyond letters and digits.
+const shortNameChars = "!#$%&'()-@^_`{}~"
+
+// isShortNameChar determines if the given character is permitted within an
+// 8.3 name.
+func isShortNameChar(c rune) bool {
+	return (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+		strings.ContainsRune(shortNameChars, c)
+}
+
+// isLegal8dot3 determines if the given name is a legal 8.3 name; that is,
+// a base of one to eight characters, and an optional extension of one to
+// three characters. As with NTFS, case is ignored, but spaces are not
+// permitted.
+func isLegal8dot3(name string) bool {
+	base, ext := name, ""
+	if i := strings.IndexByte(name, '.'); i >= 0 {
+		base, ext = name[:i], name[i+1:]
+		if len(ext) == 0 || len(ext) > 3 {
+			return false
+		}
+	}
+	if len(base) == 0 || len(base) > 8 {
+		return false
+	}
+	for _, c := range base + ext {
+		if !isShortNameChar(c) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsShortName determines if the given name has the form of an alias
+// generated by GenerateShortName(), such as ``PROGRA~1''; which may be used
+// to refer to a file by other than its long name.
+func IsShortName(name string) bool {
+	if !isLegal8dot3(name) {
+		return false
+	}
+	base := name
+	if i := strings.IndexByte(name, '.'); i >= 0 {
+		base = name[:i]
+	}
+	tilde := strings.LastIndexByte(base, '~')
+	if tilde < 0 || tilde == len(base)-1 {
+		return false
+	}
+	for _, c := range base[tilde+1:] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// shortNamePart returns the given part of a long name, as it appears within
+// an 8.3 name; spaces and dots are stripped, characters not permitted are
+// replaced by an underscore, and letters are upper-cased.
+func shortNamePart(part string) string {
+	var b strings.Builder
+	for _, c := range part {
+		switch {
+		case c == ' ' || c == '.':
+		case c >= 'a' && c <= 'z':
+			b.WriteRune(c - 32)
+		case isShortNameChar(c):
+			b.WriteRune(c)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
+// shortNameHash returns the checksum of a long name, which Windows 7 and
+// later use in the 8.3 name after four collisions.
+func shortNameHash(name string) uint16 {
+	var sum int32
+	for _, c := range utf16.Encode([]rune(name)) {
+		sum = sum*0x25 + int32(c)
+	}
+	temp := sum * 314159269
+	if temp < 0 {
+		temp = -temp
+	}
+	temp -= int32(uint64(int64(temp)*1152921497)>>60) * 1000000007
+	// the nibbles are reversed
+	return uint16((temp&0xf000)>>12 | (temp&0x0f00)>>4 | (temp&0x00f0)<<4 | (temp&0x000f)<<12)
+}
+
+// GenerateShortName returns the 8.3 alias NTFS and FAT generate for the
+// given long name, within a directory already holding the existing long
+// and short names; which are compared ignoring case. A name already legal
+// as an 8.3 name is its own alias, so it is returned upper-cased. The alias
+// is built:
+//	1. Leading dots, spaces and every dot but the last are stripped
+//	2. Characters not permitted in an 8.3 name, including those beyond
+//	   ASCII, become ``_''; while letters are upper-cased
+//	3. The first six characters of the base are followed by ``~1'', and
+//	   the first three characters of the extension; as in ``PROGRA~1''
+//	4. Upon collision, the counter is increased up to ``~4''
+//	5. Further collisions use the first two characters of the base,
+//	   followed by a checksum of the long name, and a counter beginning
+//	   again at ``~1''; as in ``PRxxxx~1''
+// An empty string is returned for a name from which no alias may be built.
+func GenerateShortName(name string, existing []string) string {
+	if isLegal8dot3(name) {
+		return strings.ToUpper(name)
+	}
+
+	long := strings.TrimLeft(name, ".")
+	base, ext := long, ""
+	if i := strings.LastIndexByte(long, '.'); i >= 0 {
+		base, ext = long[:i], long[i+1:]
+	}
+	base, ext = shortNamePart(base), shortNamePart(ext)
+	if len(ext) > 3 {
+		ext = ext[:3]
+	}
+	if len(ext) > 0 {
+		ext = "." + ext
+	}
+	if len(base) == 0 && len(ext) == 0 {
+		return ""
+	}
+
+	hashed := base
+	if len(hashed) > 2 {
+		hashed = hashed[:2]
+	}
+	hashed += fmt.Sprintf("%04X", shortNameHash(name))
+
+	for n := 1; ; n++ {
+		prefix, counter := base, n
+		if n > 4 {
+			prefix, counter = hashed, n-4
+		}
+		suffix := fmt.Sprintf("~%d", counter)
+		if len(suffix) > 7 {
+			return ""
+		}
+		if len(prefix)+len(suffix) > 8 {
+			prefix = prefix[:8-len(suffix)]
+		}
+		alias := prefix + suffix + ext
+		if !containsUpcase(existing, alias) {
+			return alias
+		}
+	}
+}
+
+// containsUpcase determines if any of the names equals the given name,
+// ignoring case.
+func containsUpcase(names []string, name string) bool {
+	for _, other := range names {
+		if equalUpcase(other, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithShortName returns a new Path, recording the given 8.3 alias of its
+// Name(); such as one returned by GenerateShortName(). An alias which is
+// not a legal 8.3 name is recorded as an error.
+func (p *PathImpl) WithShortName(alias string) *PathImpl {
+	aliased := p.clone()
+	aliased.shortName, aliased.shortOf = alias, p.shortKey()
+	if !isLegal8dot3(alias) {
+		aliased.addError(ErrInvalidShortName, -1, len(p.dirs), 0)
+	}
+	return aliased
+}
+
+// ShortName returns the 8.3 alias recorded by WithShortName(); or an empty
+// string when none was recorded, or the components have since changed.
+func (p *PathImpl) ShortName() string {
+	if len(p.shortName) == 0 || p.shortOf != p.shortKey() {
+		return ""
+	}
+	return p.shortName
+}
+
+// shortKey identifies the component given an alias by WithShortName(); its
+// directories, along with its name, so an alias does not follow the name to
+// another component, such as after Parent() or Join().
+func (p *PathImpl) shortKey() string {
+	return strings.Join(append(p.dirs[:len(p.dirs):len(p.dirs)], p.name), "\\")
+}
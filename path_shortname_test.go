@@ -0,0 +1,91 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package windows_test
+
+import (
+	"gitlab.com/jbenden/windows"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ShortName", func() {
+	DescribeTable("when generating an 8.3 alias",
+		func(name string, existing []string, expected string) {
+			Expect(windows.GenerateShortName(name, existing)).To(Equal(expected))
+		},
+		Entry("legal 8.3 name", "readme.txt", nil, "README.TXT"),
+		Entry("long name", "Program Files", nil, "PROGRA~1"),
+		Entry("long base", "abcdefghij.txt", nil, "ABCDEF~1.TXT"),
+		Entry("long extension", "index.html", nil, "INDEX~1.HTM"),
+		Entry("spaces", "my file.txt", nil, "MYFILE~1.TXT"),
+		Entry("leading dot", ".bashrc", nil, "BASHRC~1"),
+		Entry("many dots", "a.b.c.d", nil, "ABC~1.D"),
+		Entry("replaced characters", "a+b=c[d].txt", nil, "A_B_C_~1.TXT"),
+		Entry("beyond ASCII", "ünïcode.txt", nil, "_N_COD~1.TXT"),
+		Entry("collision", "Program Files", []string{"PROGRA~1"}, "PROGRA~2"),
+		Entry("collision ignoring case", "Program Files", []string{"progra~1", "Progra~2"}, "PROGRA~3"),
+		Entry("collision with extension", "abcdefghij.txt", []string{"ABCDEF~1.TXT"}, "ABCDEF~2.TXT"),
+		Entry("only dots", "...", nil, ""),
+	)
+
+	It("uses a checksum after four collisions", func() {
+		existing := []string{"ABCDEF~1.TXT", "ABCDEF~2.TXT", "ABCDEF~3.TXT", "ABCDEF~4.TXT"}
+		alias := windows.GenerateShortName("abcdefghij.txt", existing)
+		Expect(alias).To(MatchRegexp(`^AB[0-9A-F]{4}~1\.TXT$`))
+		Expect(windows.GenerateShortName("abcdefghij.txt", existing)).To(Equal(alias))
+
+		next := windows.GenerateShortName("abcdefghij.txt", append(existing, alias))
+		Expect(next).To(Equal(alias[:6] + "~2.TXT"))
+	})
+
+	DescribeTable("when detecting an 8.3 alias",
+		func(name string, expected bool) {
+			Expect(windows.IsShortName(name)).To(Equal(expected))
+		},
+		Entry("alias", "PROGRA~1", true),
+		Entry("alias with extension", "ABCDEF~2.TXT", true),
+		Entry("checksum alias", "AB12CD~1.TXT", true),
+		Entry("lower-case alias", "progra~1", true),
+		Entry("legal 8.3 name", "README.TXT", false),
+		Entry("tilde without counter", "ABC~.TXT", false),
+		Entry("long name", "Program Files~1", false),
+	)
+
+	It("records the alias of a Path", func() {
+		path := windows.Path(`C:\Program Files`)
+		aliased := path.WithShortName(windows.GenerateShortName(path.Name(), nil))
+		Expect(aliased.ShortName()).To(Equal("PROGRA~1"))
+		Expect(aliased.Err()).To(BeNil())
+		Expect(path.ShortName()).To(BeEmpty())
+		Expect(aliased.WithName("Windows").ShortName()).To(BeEmpty())
+	})
+
+	It("drops the alias when another component becomes the name", func() {
+		aliased := windows.Path(`C:\x\a\a`).WithShortName("A~1")
+		Expect(aliased.ShortName()).To(Equal("A~1"))
+		Expect(aliased.Parent().ShortName()).To(BeEmpty())
+		Expect(windows.Path(`C:\x\a`).WithShortName("A~1").Join("a").ShortName()).To(BeEmpty())
+		Expect(aliased.WithDirs([]string{"y", "a"}).ShortName()).To(BeEmpty())
+		Expect(windows.Path(`C:\x\a\..\a`).WithShortName("A~1").Clean().ShortName()).To(BeEmpty())
+	})
+
+	It("records an error for an invalid alias", func() {
+		aliased := windows.Path(`C:\Program Files`).WithShortName("Program Files")
+		Expect(aliased.Errors()).To(ContainElement(MatchError(windows.ErrInvalidShortName)))
+	})
+})
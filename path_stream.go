@@ -0,0 +1,137 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package windows
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidStream indicates a malformed alternate data stream was given
+// after a file name; such as an empty stream name or an unknown stream type.
+var ErrInvalidStream = errors.New("path: invalid stream specified")
+
+// streamTypes are the NTFS attribute types which may name a stream.
+var streamTypes = []string{
+	"$ATTRIBUTE_LIST",
+	"$BITMAP",
+	"$DATA",
+	"$EA",
+	"$EA_INFORMATION",
+	"$FILE_NAME",
+	"$INDEX_ALLOCATION",
+	"$INDEX_ROOT",
+	"$LOGGED_UTILITY_STREAM",
+	"$OBJECT_ID",
+	"$REPARSE_POINT",
+	"$SECURITY_DESCRIPTOR",
+	"$STANDARD_INFORMATION",
+	"$VOLUME_INFORMATION",
+	"$VOLUME_NAME",
+}
+
+// isStreamNameLetter determines if the given rune is valid for a stream name.
+// Unlike file names, control characters are allowed.
+func isStreamNameLetter(c rune) bool {
+	switch c {
+	case 0, '\\', '/', ':':
+		return false
+	}
+	return true
+}
+
+// setStream parses and records the stream specification following the
+// first colon of a file name; that is ``name'', ``name:$TYPE'' or
+// ``:$TYPE'', found at the given rune offset. A malformed specification is
+// recorded as an error, and false is returned; so the caller keeps its text
+// within the file name, just as with any other invalid name.
+func (p *PathImpl) setStream(spec string, offset int) bool {
+	name, streamType := spec, ""
+	if i := strings.IndexByte(spec, ':'); i >= 0 {
+		name, streamType = spec[:i], spec[i+1:]
+		if len(streamType) == 0 || !isStreamType(streamType) {
+			p.addError(ErrInvalidStream, offset+utf8.RuneCountInString(name)+1, len(p.dirs), 0)
+			return false
+		}
+	} else if len(name) == 0 {
+		p.addError(ErrInvalidStream, offset, len(p.dirs), 0)
+		return false
+	}
+
+	for _, c := range name {
+		if !isStreamNameLetter(c) {
+			p.addError(ErrInvalidStream, offset, len(p.dirs), c)
+			return false
+		}
+		offset++
+	}
+
+	p.stream = name
+	p.streamType = strings.ToUpper(streamType)
+	return true
+}
+
+// withStreamOf sets the stream of a Path, newly built from the components
+// of another, to the stream of the other; and returns the Path.
+func (p *PathImpl) withStreamOf(other *PathImpl) *PathImpl {
+	p.stream, p.streamType = other.stream, other.streamType
+	return p
+}
+
+// isStreamType determines if the given text is a known stream type.
+func isStreamType(streamType string) bool {
+	for _, t := range streamTypes {
+		if strings.EqualFold(t, streamType) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeStream writes the stream suffix of the parsed Path, if any.
+func (p *PathImpl) writeStream(buf *bytes.Buffer) {
+	if !p.HasStream() {
+		return
+	}
+	buf.WriteString(":")
+	buf.WriteString(p.stream)
+	if len(p.streamType) > 0 {
+		buf.WriteString(":")
+		buf.WriteString(p.streamType)
+	}
+}
+
+// HasStream checks whether the Path refers to an alternate data stream of
+// a file, or to an explicitly typed stream; such as ``file.txt::$DATA''.
+func (p *PathImpl) HasStream() bool {
+	return len(p.stream) > 0 || len(p.streamType) > 0
+}
+
+// Stream returns the alternate data stream name from a parsed path; such as
+// ``Zone.Identifier'' from ``file.txt:Zone.Identifier:$DATA''. The default
+// unnamed data stream is an empty string.
+func (p *PathImpl) Stream() string {
+	return p.stream
+}
+
+// StreamType returns the stream type from a parsed path; such as ``$DATA''
+// from ``file.txt:Zone.Identifier:$DATA''.
+func (p *PathImpl) StreamType() string {
+	return p.streamType
+}
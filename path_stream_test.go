@@ -0,0 +1,108 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package windows_test
+
+import (
+	"gitlab.com/jbenden/windows"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Stream", func() {
+	DescribeTable("when an alternate data stream is present",
+		func(target string, name string, stream string, streamType string, unicode string) {
+			subject := windows.Path(target)
+
+			Expect(subject).ShouldNot(BeNil())
+			Expect(subject.Errors()).To(BeEmpty())
+			Expect(subject.HasStream()).To(BeTrue())
+			Expect(subject.Name()).To(Equal(name))
+			Expect(subject.Stream()).To(Equal(stream))
+			Expect(subject.StreamType()).To(Equal(streamType))
+			Expect(subject.ToString()).To(Equal(target))
+			Expect(subject.ToUnicodeUNC()).To(Equal(unicode))
+		},
+		Entry("a named stream", "C:\\file.txt:Zone.Identifier", "file.txt", "Zone.Identifier", "", "\\\\?\\C:\\file.txt:Zone.Identifier"),
+		Entry("a named and typed stream", "C:\\dir\\file.txt:Zone.Identifier:$DATA", "file.txt", "Zone.Identifier", "$DATA", "\\\\?\\C:\\dir\\file.txt:Zone.Identifier:$DATA"),
+		Entry("the default data stream", "C:\\file.txt::$DATA", "file.txt", "", "$DATA", "\\\\?\\C:\\file.txt::$DATA"),
+		Entry("a directory index", "C:\\dir:$I30:$INDEX_ALLOCATION", "dir", "$I30", "$INDEX_ALLOCATION", "\\\\?\\C:\\dir:$I30:$INDEX_ALLOCATION"),
+		Entry("a stream of a UNC file", "\\\\peaches\\msys64\\file:s", "file", "s", "", "\\\\?\\UNC\\peaches\\msys64\\file:s"),
+	)
+
+	Context("when a stream type is not upper-case", func() {
+		It("should have the canonical stream type", func() {
+			Expect(windows.Path("C:\\file.txt:s:$data").StreamType()).To(Equal("$DATA"))
+		})
+	})
+
+	Context("when a stream name has a control character", func() {
+		It("should not have errors present", func() {
+			Expect(windows.Path("C:\\file.txt:a\x01b").Errors()).To(BeEmpty())
+		})
+	})
+
+	DescribeTable("when a malformed stream is present",
+		func(target string) {
+			subject := windows.Path(target)
+
+			Expect(subject).ShouldNot(BeNil())
+			Expect(subject.HasStream()).To(BeFalse())
+			Expect(subject.Errors()).To(ContainElement(MatchError(windows.ErrInvalidStream)))
+			Expect(subject.ToString()).To(Equal(target))
+		},
+		Entry("an empty stream", "C:\\file.txt:"),
+		Entry("an empty stream of a relative file", "file.txt:"),
+		Entry("an empty stream type", "C:\\file.txt:s:"),
+		Entry("a stream type without a dollar sign", "C:\\file.txt:s:DATA"),
+		Entry("an unknown stream type", "C:\\file.txt:s:$NOPE"),
+		Entry("too many colons", "C:\\file.txt:a:b:$DATA"),
+	)
+
+	Context("when a directory names a stream", func() {
+		It("should have errors present", func() {
+			subject := windows.Path("C:\\dir:s\\file.txt")
+
+			Expect(subject.HasStream()).To(BeFalse())
+			Expect(subject.Errors()).Should(ContainElement(WithTransform(ErrString, ContainSubstring("reserved character"))))
+		})
+	})
+
+	Context("when joining paths", func() {
+		It("should keep the stream of the joined path", func() {
+			subject := windows.Path("C:\\dir").JoinPath(windows.Path("file.txt:s"))
+
+			Expect(subject.ToString()).To(Equal("C:\\dir\\file.txt:s"))
+		})
+
+		It("should drop the stream of a parent", func() {
+			subject := windows.Path("C:\\dir:s").Join("file.txt")
+
+			Expect(subject.HasStream()).To(BeFalse())
+		})
+	})
+
+	Context("when no stream is present", func() {
+		It("should not have a stream", func() {
+			subject := windows.Path("C:\\file.txt")
+
+			Expect(subject.HasStream()).To(BeFalse())
+			Expect(subject.Stream()).To(BeEmpty())
+			Expect(subject.StreamType()).To(BeEmpty())
+		})
+	})
+})
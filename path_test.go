@@ -17,7 +17,6 @@ package windows_test
 
 import (
 	"math/rand"
-	"strings"
 	"time"
 
 	"gitlab.com/jbenden/windows"
@@ -59,6 +58,17 @@ func RandStringBytesMaskImprSrc(n int) string {
 	return string(b)
 }
 
+// RandPathBytesMaskImprSrc generates a relative path of a specified length,
+// with components of at most 100 characters.
+func RandPathBytesMaskImprSrc(n int) string {
+	b := []byte(RandStringBytesMaskImprSrc(n))
+	for i := 100; i < n-1; i += 101 {
+		b[i] = '\\'
+	}
+
+	return string(b)
+}
+
 // ErrString is a helper used with Gomega's WithTransform function, to return the string inside an error.
 func ErrString(e error) string {
 	return e.Error()
@@ -112,7 +122,7 @@ var _ = Describe("Path", func() {
 			Expect(subject.IsAbsolute()).To(BeFalse())
 			Expect(subject.IsRelative()).To(BeTrue())
 			Expect(subject.ToUnicodeUNC()).To(Equal("\\\\?\\C:\\"))
-			Expect(subject.ToString()).To(Equal("C:\\"))
+			Expect(subject.ToString()).To(Equal("C:"))
 		},
 		Entry("a lower-case drive", "c:"),
 		Entry("a upper-case drive", "C:"),
@@ -124,23 +134,18 @@ var _ = Describe("Path", func() {
 
 			Expect(subject).ShouldNot(BeNil())
 
-			var expecting string
-			if strings.HasPrefix(target, "\\\\?\\") {
-				expecting = "UNICODE path exceeds"
-			} else {
-				expecting = "path exceeds"
-			}
-
 			if expected {
-				Expect(subject.Errors()).Should(ContainElement(WithTransform(ErrString, ContainSubstring(expecting))))
+				Expect(subject.Errors()).Should(ContainElement(MatchError(windows.ErrTooLong)))
 			} else {
-				Expect(subject.Errors()).ShouldNot(ContainElement(WithTransform(ErrString, ContainSubstring(expecting))))
+				Expect(subject.Errors()).ShouldNot(ContainElement(MatchError(windows.ErrTooLong)))
 			}
 		},
-		Entry("a non-UNICODE path, just under", RandStringBytesMaskImprSrc(255), false),
-		Entry("a non-UNICODE path", RandStringBytesMaskImprSrc(256), true),
-		Entry("a UNICODE path, just under", "\\\\?\\"+RandStringBytesMaskImprSrc(32762), false),
-		Entry("a UNICODE path", "\\\\?\\"+RandStringBytesMaskImprSrc(34000), true),
+		Entry("a non-UNICODE path, just under", RandPathBytesMaskImprSrc(259), false),
+		Entry("a non-UNICODE path", RandPathBytesMaskImprSrc(260), true),
+		Entry("a UNICODE path, just under", "\\\\?\\"+RandPathBytesMaskImprSrc(32762), false),
+		Entry("a UNICODE path", "\\\\?\\"+RandPathBytesMaskImprSrc(34000), true),
+		Entry("a component, just under", RandStringBytesMaskImprSrc(255), false),
+		Entry("a component", RandStringBytesMaskImprSrc(256), true),
 	)
 
 	Context("when only a drive letter is present", func() {
@@ -264,6 +269,10 @@ var _ = Describe("Path", func() {
 			Expect(subject).ShouldNot(BeNil())
 		})
 
+		It("should not have errors present", func() {
+			Expect(subject.Errors()).To(BeEmpty())
+		})
+
 		It("should have the correct device/drive", func() {
 			Expect(subject.Device()).To(Equal("C"))
 		})
@@ -296,8 +305,9 @@ var _ = Describe("Path", func() {
 			Expect(subject.Node()).To(Equal("peaches"))
 		})
 
-		It("should have the correct path name present", func() {
-			Expect(subject.Name()).To(Equal("msys64"))
+		It("should have the correct share present", func() {
+			Expect(subject.Share()).To(Equal("msys64"))
+			Expect(subject.Name()).To(BeEmpty())
 		})
 
 		It("should not be a local path", func() {
@@ -325,8 +335,9 @@ var _ = Describe("Path", func() {
 			Expect(subject.Node()).To(Equal("peaches"))
 		})
 
-		It("should have the correct path name present", func() {
-			Expect(subject.Name()).To(Equal("msys64"))
+		It("should have the correct share present", func() {
+			Expect(subject.Share()).To(Equal("msys64"))
+			Expect(subject.Name()).To(BeEmpty())
 		})
 
 		It("should not be a local path", func() {
@@ -363,7 +374,8 @@ var _ = Describe("Path", func() {
 		})
 
 		It("should have the correct paths present", func() {
-			Expect(subject.Dirs()).To(BeEquivalentTo([]string{"msys64", "home"}))
+			Expect(subject.Share()).To(Equal("msys64"))
+			Expect(subject.Dirs()).To(BeEquivalentTo([]string{"home"}))
 		})
 
 		It("should not be a local path", func() {
@@ -449,8 +461,8 @@ var _ = Describe("Path", func() {
 					subject = windows.Path("\\msys64").MakeAbsolute()
 				})
 
-				It("should not have a disk/device present", func() {
-					Expect(subject.Device()).Should(BeEmpty())
+				It("should have the current disk/device present", func() {
+					Expect(subject.Device()).Should(Equal("C"))
 				})
 
 				It("should have the correct path", func() {
@@ -462,7 +474,7 @@ var _ = Describe("Path", func() {
 				})
 
 				It("should exist in the file system", func() {
-					Expect(subject.ToString()).To(Equal("\\msys64"))
+					Expect(subject.ToString()).To(Equal("C:\\msys64"))
 					Expect(subject.IsDirectoryExists()).To(BeTrue())
 				})
 			})
@@ -545,8 +557,8 @@ var _ = Describe("Path", func() {
 					subject = windows.Path("\\msys64").MakeAbsolute()
 				})
 
-				It("should not have a disk/device present", func() {
-					Expect(subject.Device()).Should(BeEmpty())
+				It("should have the current disk/device present", func() {
+					Expect(subject.Device()).Should(Equal("C"))
 				})
 
 				It("should have the correct path", func() {
@@ -558,7 +570,7 @@ var _ = Describe("Path", func() {
 				})
 
 				It("should exist in the file system", func() {
-					Expect(subject.ToString()).To(Equal("\\msys64"))
+					Expect(subject.ToString()).To(Equal("C:\\msys64"))
 					Expect(subject.IsDirectoryExists()).To(BeTrue())
 				})
 			})
@@ -0,0 +1,84 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package windows
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrTrailingDotOrSpace indicates a file or directory name ends with a dot
+// or a space. The Win32 layer silently strips these, while a UNICODE path
+// keeps them; creating names which most applications cannot open or delete.
+var ErrTrailingDotOrSpace = errors.New("path: a name ends with a dot or space")
+
+// hasTrailingDotOrSpace determines if the given file or directory name ends
+// with a dot or a space; ignoring the "." and ".." relative components.
+func hasTrailingDotOrSpace(name string) bool {
+	if name == "." || name == ".." {
+		return false
+	}
+	return strings.HasSuffix(name, ".") || strings.HasSuffix(name, " ")
+}
+
+// trimTrailing applies the Win32 normalization of trailing characters to a
+// component. A single trailing dot is removed from every component, while
+// all trailing dots and spaces are removed from the final component, unless
+// it is followed by a backslash.
+func trimTrailing(comp string, final bool) string {
+	switch {
+	case comp == "." || comp == "..":
+		return comp
+	case final:
+		return strings.TrimRight(comp, ". ")
+	case strings.HasSuffix(comp, ".") && !strings.HasSuffix(comp, ".."):
+		return comp[:len(comp)-1]
+	}
+	return comp
+}
+
+// TrimTrailing returns a new Path, with trailing dots and spaces removed
+// from its components just as the Win32 layer would. UNICODE and device
+// namespace paths are returned unchanged, as Windows passes them to the
+// file system without normalization.
+func (p *PathImpl) TrimTrailing() *PathImpl {
+	if p.unicode || p.devicePath {
+		return p
+	}
+
+	trimmed := p.clone()
+	for i, dir := range trimmed.dirs {
+		trimmed.dirs[i] = trimTrailing(dir, false)
+	}
+	trimmed.name = trimTrailing(trimmed.name, true)
+
+	trimmed.errs = trimmed.errs[:0]
+	for _, err := range p.errs {
+		if !errors.Is(err, ErrTrailingDotOrSpace) {
+			trimmed.errs = append(trimmed.errs, err)
+		}
+	}
+	for i, comp := range trimmed.components() {
+		if hasTrailingDotOrSpace(comp) {
+			last, _ := utf8.DecodeLastRuneInString(comp)
+			trimmed.addError(ErrTrailingDotOrSpace, -1, i, last)
+		}
+	}
+
+	return trimmed
+}
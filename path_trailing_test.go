@@ -0,0 +1,84 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package windows_test
+
+import (
+	"gitlab.com/jbenden/windows"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Trailing dots and spaces", func() {
+	DescribeTable("when a name ends with a dot or space",
+		func(target string, count int) {
+			subject := windows.Path(target)
+
+			Expect(subject).ShouldNot(BeNil())
+			Expect(subject.Errors()).To(HaveLen(count))
+			Expect(subject.Errors()).To(ContainElement(MatchError(windows.ErrTrailingDotOrSpace)))
+		},
+		Entry("a file with a trailing dot", "C:\\file.", 1),
+		Entry("a file with a trailing space", "C:\\file ", 1),
+		Entry("a directory with a trailing dot", "C:\\dir.\\file", 1),
+		Entry("a name of three dots", "C:\\...", 1),
+		Entry("multiple components", "C:\\dir \\file. . ", 2),
+		Entry("a UNICODE path", "\\\\?\\C:\\dir \\file", 1),
+	)
+
+	DescribeTable("when relative components are present",
+		func(target string) {
+			Expect(windows.Path(target).Errors()).To(BeEmpty())
+		},
+		Entry("a current directory", "C:\\dir\\."),
+		Entry("a parent directory", "C:\\dir\\..\\file"),
+	)
+
+	DescribeTable("when trimming as the Win32 layer would",
+		func(target string, dirs []string, name string) {
+			subject := windows.Path(target).TrimTrailing()
+
+			Expect(subject.Dirs()).To(BeEquivalentTo(dirs))
+			Expect(subject.Name()).To(Equal(name))
+		},
+		Entry("a file", "C:\\dir\\file. . ", []string{"dir"}, "file"),
+		Entry("a directory with a single trailing dot", "C:\\dir.\\file", []string{"dir"}, "file"),
+		Entry("a directory with a trailing space", "C:\\dir \\file", []string{"dir "}, "file"),
+		Entry("a directory with many trailing dots", "C:\\dir..\\file", []string{"dir.."}, "file"),
+		Entry("a final directory", "C:\\dir \\", []string{"dir "}, ""),
+		Entry("a UNICODE path", "\\\\?\\C:\\dir.\\file.", []string{"dir."}, "file."),
+	)
+
+	Context("when trimming a file name", func() {
+		It("should no longer have errors present", func() {
+			Expect(windows.Path("C:\\dir\\file.").TrimTrailing().Errors()).To(BeEmpty())
+		})
+
+		It("should keep errors for untrimmed names", func() {
+			Expect(windows.Path("C:\\dir \\file.").TrimTrailing().Errors()).To(ConsistOf(MatchError(windows.ErrTrailingDotOrSpace)))
+		})
+	})
+
+	Context("when resolving a full path", func() {
+		It("should trim as the Win32 layer would", func() {
+			subject, err := windows.Path("C:\\dir.\\file .").FullPath(nil)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(subject.ToString()).To(Equal("C:\\dir\\file"))
+		})
+	})
+})
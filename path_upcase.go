@@ -0,0 +1,103 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package windows
+
+import "sync"
+
+// The default NTFS $UpCase table, as formatted by Windows NT through XP and
+// by the Linux NTFS driver; being the identity, altered by the three tables
+// below. Unlike unicode.ToUpper, it is fixed; so comparisons never change
+// with the version of Unicode Go is built with. Later versions of Windows
+// add mappings for letters since added to Unicode, which are not made here.
+var (
+	// upcaseRuns add an offset to each code unit from start up to end.
+	upcaseRuns = [...][3]int{ // start, end, add
+		{0x0061, 0x007B, -32}, {0x00E0, 0x00F7, -32}, {0x00F8, 0x00FF, -32},
+		{0x0256, 0x0258, -205}, {0x028A, 0x028C, -217}, {0x03AC, 0x03AD, -38},
+		{0x03AD, 0x03B0, -37}, {0x03B1, 0x03C2, -32}, {0x03C2, 0x03C3, -31},
+		{0x03C3, 0x03CC, -32}, {0x03CC, 0x03CD, -64}, {0x03CD, 0x03CF, -63},
+		{0x0430, 0x0450, -32}, {0x0451, 0x045D, -80}, {0x045E, 0x0460, -80},
+		{0x0561, 0x0587, -48}, {0x1F00, 0x1F08, 8}, {0x1F10, 0x1F16, 8},
+		{0x1F20, 0x1F28, 8}, {0x1F30, 0x1F38, 8}, {0x1F40, 0x1F46, 8},
+		{0x1F51, 0x1F52, 8}, {0x1F53, 0x1F54, 8}, {0x1F55, 0x1F56, 8},
+		{0x1F57, 0x1F58, 8}, {0x1F60, 0x1F68, 8}, {0x1F70, 0x1F72, 74},
+		{0x1F72, 0x1F76, 86}, {0x1F76, 0x1F78, 100}, {0x1F78, 0x1F7A, 128},
+		{0x1F7A, 0x1F7C, 112}, {0x1F7C, 0x1F7E, 126}, {0x1FB0, 0x1FB2, 8},
+		{0x1FD0, 0x1FD2, 8}, {0x1FE0, 0x1FE2, 8}, {0x1FE5, 0x1FE6, 7},
+		{0x2170, 0x2180, -16}, {0x24D0, 0x24EA, -26}, {0xFF41, 0xFF5B, -32},
+	}
+	// upcasePairs map each second code unit from start up to end to the
+	// one before it; for alphabets alternating upper and lower case.
+	upcasePairs = [...][2]int{ // start, end
+		{0x0100, 0x012F}, {0x0132, 0x0137}, {0x0139, 0x0149}, {0x014A, 0x0178},
+		{0x0179, 0x017E}, {0x01A0, 0x01A6}, {0x01B3, 0x01B7}, {0x01CD, 0x01DD},
+		{0x01DE, 0x01EF}, {0x01F4, 0x01F5}, {0x01FA, 0x0218}, {0x03E2, 0x03EF},
+		{0x0460, 0x0481}, {0x0490, 0x04BF}, {0x04C1, 0x04C4}, {0x04C7, 0x04C8},
+		{0x04CB, 0x04CC}, {0x04D0, 0x04EB}, {0x04EE, 0x04F5}, {0x04F8, 0x04F9},
+		{0x1E00, 0x1E95}, {0x1EA0, 0x1EF9},
+	}
+	// upcaseSingles map a single code unit.
+	upcaseSingles = [...][2]int{ // code unit, upper case
+		{0x00FF, 0x0178}, {0x0183, 0x0182}, {0x0185, 0x0184}, {0x0188, 0x0187},
+		{0x018C, 0x018B}, {0x0192, 0x0191}, {0x0199, 0x0198}, {0x01A8, 0x01A7},
+		{0x01AD, 0x01AC}, {0x01B0, 0x01AF}, {0x01B9, 0x01B8}, {0x01BD, 0x01BC},
+		{0x01C6, 0x01C4}, {0x01C9, 0x01C7}, {0x01CC, 0x01CA}, {0x01DD, 0x018E},
+		{0x01F3, 0x01F1}, {0x0253, 0x0181}, {0x0254, 0x0186}, {0x0259, 0x018F},
+		{0x025B, 0x0190}, {0x0260, 0x0193}, {0x0263, 0x0194}, {0x0268, 0x0197},
+		{0x0269, 0x0196}, {0x026F, 0x019C}, {0x0272, 0x019D}, {0x0275, 0x019F},
+		{0x0283, 0x01A9}, {0x0288, 0x01AE}, {0x0292, 0x01B7},
+	}
+)
+
+var (
+	upcaseOnce  sync.Once
+	upcaseTable []uint16
+)
+
+// buildUpcase generates the $UpCase table from its runs, pairs and singles.
+func buildUpcase() []uint16 {
+	table := make([]uint16, 0x10000)
+	for i := range table {
+		table[i] = uint16(i)
+	}
+	for _, pair := range upcasePairs {
+		for i := pair[0]; i < pair[1]; i += 2 {
+			table[i+1]--
+		}
+	}
+	for _, run := range upcaseRuns {
+		for i := run[0]; i < run[1]; i++ {
+			table[i] = uint16(int(table[i]) + run[2])
+		}
+	}
+	for _, single := range upcaseSingles {
+		table[single[0]] = uint16(single[1])
+	}
+	return table
+}
+
+// upcase maps a UTF-16 code unit to upper case, just as the NTFS $UpCase
+// table does. The table holds a simple mapping for each code unit, so a
+// surrogate is never mapped, nor is anything beyond the Basic Multilingual
+// Plane.
+func upcase(c rune) rune {
+	if c < 0 || c >= 0x10000 {
+		return c
+	}
+	upcaseOnce.Do(func() { upcaseTable = buildUpcase() })
+	return rune(upcaseTable[c])
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package windows
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// ErrInvalidFileURI indicates a malformed ``file'' URI.
+var ErrInvalidFileURI = errors.New("path: invalid file URI")
+
+// isURIPathByte determines if the given byte may be written unescaped in a
+// segment of a URI path, as given by RFC 3986; other than a colon, so a
+// stream is never confused with a drive.
+func isURIPathByte(c byte) bool {
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		return true
+	}
+	return strings.IndexByte("-._~!$&'()*+,;=@", c) >= 0
+}
+
+// escapeURISegment percent-encodes a segment of a URI path.
+func escapeURISegment(s string) string {
+	const hex = "0123456789ABCDEF"
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; isURIPathByte(c) {
+			b.WriteByte(c)
+		} else {
+			b.WriteByte('%')
+			b.WriteByte(hex[c>>4])
+			b.WriteByte(hex[c&15])
+		}
+	}
+	return b.String()
+}
+
+// ToFileURI returns the ``file'' URI of a fully-qualified Path, as given
+// by RFC 8089; such as ``file:///C:/src/app'' for ``C:\src\app'', or
+// ``file://server/share/app'' for ``\\server\share\app''. A UNICODE Path is
+// written without its ``\\?\'' prefix, as URIs have no namespaces. Each
+// component is percent-encoded as UTF-8.
+//
+// ErrNotFullyQualified is returned for a relative Path, while
+// ErrNotRepresentable is returned for a device, volume or NT path.
+func (p *PathImpl) ToFileURI() (string, error) {
+	var uri strings.Builder
+	uri.WriteString("file://")
+
+	switch {
+	case p.devicePath || p.volume != nil || len(p.ntPrefix) > 0 || p.rootDevice:
+		return "", ErrNotRepresentable
+	case p.unc:
+		uri.WriteString(escapeURISegment(p.node))
+		if len(p.share) > 0 {
+			uri.WriteString("/")
+			uri.WriteString(escapeURISegment(p.share))
+		}
+		if len(p.dirs) > 0 || len(p.name) > 0 {
+			uri.WriteString("/")
+		}
+	case len(p.device) > 0 && p.absolute:
+		uri.WriteString("/")
+		uri.WriteString(p.device)
+		uri.WriteString(":/")
+	default:
+		return "", ErrNotFullyQualified
+	}
+
+	for _, dir := range p.dirs {
+		uri.WriteString(escapeURISegment(dir))
+		uri.WriteString("/")
+	}
+	name := p.name
+	if len(p.stream) > 0 || len(p.streamType) > 0 {
+		name += ":" + p.stream
+		if len(p.streamType) > 0 {
+			name += ":" + p.streamType
+		}
+	}
+	uri.WriteString(escapeURISegment(name))
+
+	return uri.String(), nil
+}
+
+// FileURIToPath parses a ``file'' URI into a Path, accepting the forms
+// given by RFC 8089; such as ``file:///C:/src/app'', ``file:/C:/src/app'',
+// ``file://localhost/C:/src/app'', and ``file://server/share/app'', along
+// with the legacy ``file:///C|/src/app'' and ``file:////server/share/app''
+// forms. Any query or fragment is ignored, and a URI without a drive or
+// host is a root-relative Path.
+//
+// A Path whose components Win32 would change, such as a name ending with a
+// dot, is returned as a UNICODE Path; so it names the same file as the URI.
+func FileURIToPath(uri string) (*PathImpl, error) {
+	if len(uri) < 5 || !strings.EqualFold(uri[:5], "file:") {
+		return nil, ErrInvalidFileURI
+	}
+	rest := uri[5:]
+	if i := strings.IndexAny(rest, "?#"); i >= 0 {
+		rest = rest[:i]
+	}
+
+	host := ""
+	if strings.HasPrefix(rest, "//") {
+		rest = rest[2:]
+		i := strings.IndexByte(rest, '/')
+		if i < 0 {
+			i = len(rest)
+		}
+		host, rest = rest[:i], rest[i:]
+	}
+	// unescape each segment on its own, so an escaped separator can not
+	// split a name in two
+	segments := strings.Split(rest, "/")
+	for i, segment := range segments {
+		name, err := url.PathUnescape(segment)
+		if err != nil || strings.ContainsAny(name, "/\\") {
+			return nil, ErrInvalidFileURI
+		}
+		segments[i] = name
+	}
+	path := strings.Join(segments, "/")
+	host, err := url.PathUnescape(host)
+	if err != nil || strings.ContainsAny(host, "/\\") {
+		return nil, ErrInvalidFileURI
+	}
+	if strings.EqualFold(host, "localhost") {
+		host = ""
+	}
+
+	// a drive letter, optionally following a slash
+	drive := strings.TrimPrefix(path, "/")
+	if len(host) == 2 && (host[1] == ':' || host[1] == '|') {
+		drive, host = host+path, ""
+	}
+
+	var win string
+	switch {
+	case len(host) > 0:
+		win = "\\\\" + host + path
+	case len(drive) >= 2 && (drive[1] == ':' || drive[1] == '|') && (len(drive) == 2 || drive[2] == '/'):
+		if _, err := isDriveLetter(rune(drive[0])); err != nil {
+			return nil, ErrInvalidFileURI
+		}
+		win = drive[:1] + ":" + drive[2:]
+		if len(drive) == 2 {
+			win += "\\"
+		}
+	case strings.HasPrefix(path, "//"):
+		// the legacy UNC forms, with four or five slashes
+		win = "//" + strings.TrimLeft(path, "/")
+	default:
+		win = path
+	}
+	win = strings.Replace(win, "/", "\\", -1)
+
+	p := Path(win)
+	if p.unc || (len(p.device) > 0 && p.absolute) {
+		for _, err := range p.errs {
+			if errors.Is(err, ErrTrailingDotOrSpace) || errors.Is(err, ErrReservedName) {
+				unicode := p.clone()
+				unicode.unicode, unicode.separators = true, BackslashSeparators
+				return Path(unicode.ToUnicodeUNC()), nil
+			}
+		}
+	}
+	return p, nil
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package windows_test
+
+import (
+	"gitlab.com/jbenden/windows"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("File URI", func() {
+	DescribeTable("when converting to a file URI",
+		func(target, expected string) {
+			uri, err := windows.Path(target).ToFileURI()
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(uri).To(Equal(expected))
+		},
+		Entry("a drive path", "C:\\src\\app", "file:///C:/src/app"),
+		Entry("a drive root", "c:\\", "file:///C:/"),
+		Entry("a directory", "C:\\src\\", "file:///C:/src/"),
+		Entry("a UNC path", "\\\\server\\share\\app", "file://server/share/app"),
+		Entry("a UNC share", "\\\\server\\share", "file://server/share"),
+		Entry("a UNICODE path", "\\\\?\\C:\\src\\app.", "file:///C:/src/app."),
+		Entry("a UNICODE UNC path", "\\\\?\\UNC\\server\\share\\app", "file://server/share/app"),
+		Entry("reserved URI characters", "C:\\a b\\#1%?.txt", "file:///C:/a%20b/%231%25%3F.txt"),
+		Entry("non-ASCII characters", "C:\\\u00e9t\u00e9\\\u65e5\u672c", "file:///C:/%C3%A9t%C3%A9/%E6%97%A5%E6%9C%AC"),
+		Entry("characters permitted in URIs", "C:\\(a)+b;c=d@e~f", "file:///C:/(a)+b;c=d@e~f"),
+		Entry("a stream", "C:\\file:stream:$DATA", "file:///C:/file%3Astream%3A$DATA"),
+	)
+
+	DescribeTable("when a path has no file URI",
+		func(target string, expected error) {
+			_, err := windows.Path(target).ToFileURI()
+
+			Expect(err).To(MatchError(expected))
+		},
+		Entry("a relative path", "src\\app", windows.ErrNotFullyQualified),
+		Entry("a drive-relative path", "C:src", windows.ErrNotFullyQualified),
+		Entry("a root-relative path", "\\src", windows.ErrNotFullyQualified),
+		Entry("a device path", "\\\\.\\COM1", windows.ErrNotRepresentable),
+		Entry("a volume path", "\\\\?\\Volume{6a4b3c2d-1e0f-11e7-8c3f-806e6f6e6963}\\src", windows.ErrNotRepresentable),
+		Entry("an NT path", "\\??\\C:\\src", windows.ErrNotRepresentable),
+	)
+
+	DescribeTable("when converting from a file URI",
+		func(target, expected string) {
+			subject, err := windows.FileURIToPath(target)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(subject.ToString()).To(Equal(expected))
+		},
+		Entry("a drive path", "file:///C:/src/app", "C:\\src\\app"),
+		Entry("a minimal drive path", "file:/C:/src/app", "C:\\src\\app"),
+		Entry("a drive path without slashes", "file:c:/src/app", "C:\\src\\app"),
+		Entry("a localhost drive path", "file://localhost/C:/src/app", "C:\\src\\app"),
+		Entry("a legacy drive path", "file:///C|/src/app", "C:\\src\\app"),
+		Entry("a drive in the authority", "file://C:/src/app", "C:\\src\\app"),
+		Entry("an encoded drive", "file:///c%3A/src/app", "C:\\src\\app"),
+		Entry("a drive root", "file:///C:", "C:\\"),
+		Entry("a directory", "FILE:///C:/src/", "C:\\src\\"),
+		Entry("a UNC path", "file://server/share/app", "\\\\server\\share\\app"),
+		Entry("a legacy UNC path", "file:////server/share/app", "\\\\server\\share\\app"),
+		Entry("a five slash UNC path", "file://///server/share/app", "\\\\server\\share\\app"),
+		Entry("percent-encoding", "file:///C:/a%20b/%231%25.txt", "C:\\a b\\#1%.txt"),
+		Entry("non-ASCII characters", "file:///C:/%C3%A9t%C3%A9", "C:\\\u00e9t\u00e9"),
+		Entry("a query and fragment", "file:///C:/src/app?x=1#line", "C:\\src\\app"),
+		Entry("a trailing dot", "file:///C:/src/app.", "\\\\?\\C:\\src\\app."),
+		Entry("a reserved name on a share", "file://server/share/nul", "\\\\?\\UNC\\server\\share\\nul"),
+		Entry("a rooted path", "file:///etc/hosts", "\\etc\\hosts"),
+	)
+
+	DescribeTable("when a file URI is invalid",
+		func(target string) {
+			_, err := windows.FileURIToPath(target)
+
+			Expect(err).To(MatchError(windows.ErrInvalidFileURI))
+		},
+		Entry("another scheme", "http://server/share"),
+		Entry("a malformed escape", "file:///C:/a%zz"),
+		Entry("an invalid drive", "file:///1:/src"),
+		Entry("an escaped slash", "file:///C:/a%2Fb"),
+		Entry("an escaped backslash", "file:///C:/a%5Cb"),
+		Entry("an escaped slash in the host", "file://server%2Fx/share"),
+	)
+
+	DescribeTable("when round-tripping",
+		func(target string) {
+			uri, err := windows.Path(target).ToFileURI()
+			Expect(err).ShouldNot(HaveOccurred())
+
+			subject, err := windows.FileURIToPath(uri)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(subject.ToString()).To(Equal(target))
+		},
+		Entry("a drive path", "C:\\Program Files\\app #1\\50%.txt"),
+		Entry("a UNC path", "\\\\server\\share\\\u65e5\u672c\\"),
+		Entry("a UNICODE path", "\\\\?\\C:\\dir.\\file "),
+		Entry("a stream", "C:\\file:stream:$DATA"),
+	)
+})
@@ -0,0 +1,182 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package windows
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidGUID indicates a string is not a valid GUID.
+var ErrInvalidGUID = errors.New("path: invalid GUID specified")
+
+// ErrUnknownVolume indicates a volume GUID has no mount point in the given
+// VolumeMounts, or that no mount point contains the path.
+var ErrUnknownVolume = errors.New("path: no mount point is known for the volume")
+
+// GUID is a globally unique identifier, laid out as the Windows GUID structure.
+type GUID struct {
+	Data1 uint32
+	Data2 uint16
+	Data3 uint16
+	Data4 [8]byte
+}
+
+// ParseGUID parses a GUID in its registry format, such as
+// ``{6a4b3c2d-1e0f-11e7-8c3f-806e6f6e6963}''; the braces are optional.
+func ParseGUID(s string) (GUID, error) {
+	var g GUID
+
+	if len(s) == 38 && s[0] == '{' && s[37] == '}' {
+		s = s[1:37]
+	}
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return g, ErrInvalidGUID
+	}
+
+	var b [16]byte
+	for i, part := range []string{s[0:8], s[9:13], s[14:18], s[19:23], s[24:36]} {
+		offset := [...]int{0, 4, 6, 8, 10}[i]
+		if _, err := hex.Decode(b[offset:], []byte(part)); err != nil {
+			return g, ErrInvalidGUID
+		}
+	}
+	g.Data1 = uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+	g.Data2 = uint16(b[4])<<8 | uint16(b[5])
+	g.Data3 = uint16(b[6])<<8 | uint16(b[7])
+	copy(g.Data4[:], b[8:])
+
+	return g, nil
+}
+
+// String returns the GUID in its registry format, using lower-case
+// hexadecimal digits just as volume GUID paths do.
+func (g GUID) String() string {
+	return fmt.Sprintf("{%08x-%04x-%04x-%x-%x}", g.Data1, g.Data2, g.Data3, g.Data4[:2], g.Data4[2:])
+}
+
+// setVolume records the volume of a volume GUID path, when the given
+// component is a volume name; such as ``Volume{6a4b3c2d-...}''.
+func (p *PathImpl) setVolume(name string) bool {
+	if len(name) < 6 || !strings.EqualFold(name[:6], "Volume") {
+		return false
+	}
+	g, err := ParseGUID(name[6:])
+	if err != nil || name[6] != '{' {
+		return false
+	}
+	p.volume = &g
+	p.deviceName = name
+	return true
+}
+
+// IsVolume checks whether the Path is rooted at a volume GUID; such as
+// ``\\?\Volume{6a4b3c2d-1e0f-11e7-8c3f-806e6f6e6963}\''.
+func (p *PathImpl) IsVolume() bool {
+	return p.volume != nil
+}
+
+// Volume returns the GUID of the volume from a parsed volume GUID path.
+func (p *PathImpl) Volume() GUID {
+	if p.volume == nil {
+		return GUID{}
+	}
+	return *p.volume
+}
+
+// VolumeMounts maps a volume GUID to the mount point of the volume; either
+// a drive, such as ``C:\'', or a directory, such as ``C:\mnt\data''. It
+// allows volume GUID paths to be translated without querying the running
+// machine.
+type VolumeMounts map[GUID]string
+
+// MountedPath returns the Path of a volume GUID path, rooted at the mount
+// point of the volume from the given VolumeMounts. A Path which is not a
+// volume GUID path is returned as a copy.
+func (p *PathImpl) MountedPath(mounts VolumeMounts) (*PathImpl, error) {
+	if p.volume == nil {
+		return p.clone(), nil
+	}
+
+	mount, ok := mounts[*p.volume]
+	if !ok {
+		return nil, ErrUnknownVolume
+	}
+	mounted := Path(mount).MakeDirectory()
+	for _, comp := range p.components() {
+		mounted.push(comp)
+	}
+	if len(p.name) == 0 {
+		mounted.push("")
+	}
+	mounted.errs = append(mounted.errs, p.errs...)
+	mounted.withStreamOf(p)
+
+	return mounted, nil
+}
+
+// VolumePath returns the volume GUID path of a fully-qualified Path, using
+// the deepest mount point of the given VolumeMounts containing it.
+func (p *PathImpl) VolumePath(mounts VolumeMounts) (*PathImpl, error) {
+	if p.volume != nil {
+		return p.clone(), nil
+	}
+	if !p.isFullyQualified() {
+		return nil, ErrNotFullyQualified
+	}
+
+	clean := p.Clean()
+	comps := clean.components()
+
+	var best *GUID
+	bestLen := -1
+	for volume, mount := range mounts {
+		volume := volume
+		m := Path(mount).Clean()
+		if !strings.EqualFold(m.device, clean.device) || !strings.EqualFold(m.uncRoot(), clean.uncRoot()) || m.unc != clean.unc {
+			continue
+		}
+		mountComps := m.components()
+		if len(mountComps) > len(comps) || len(mountComps) <= bestLen {
+			continue
+		}
+		matched := true
+		for i, comp := range mountComps {
+			if !strings.EqualFold(comp, comps[i]) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			best, bestLen = &volume, len(mountComps)
+		}
+	}
+	if best == nil {
+		return nil, ErrUnknownVolume
+	}
+
+	rest := &PathImpl{}
+	for _, comp := range comps[bestLen:] {
+		rest.push(comp)
+	}
+	if len(clean.name) == 0 {
+		rest.push("")
+	}
+	return Path("\\\\?\\Volume" + best.String() + "\\" + rest.joinComponents()).withStreamOf(p), nil
+}
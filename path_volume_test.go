@@ -0,0 +1,157 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package windows_test
+
+import (
+	"gitlab.com/jbenden/windows"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Volume", func() {
+	const systemVolume = "{6a4b3c2d-1e0f-11e7-8c3f-806e6f6e6963}"
+	const dataVolume = "{0b1c2d3e-4f50-6172-8394-a5b6c7d8e9f0}"
+
+	var subject *windows.PathImpl
+
+	Context("when parsing a GUID", func() {
+		It("should parse the registry format", func() {
+			guid, err := windows.ParseGUID("{6A4B3C2D-1E0F-11E7-8C3F-806E6F6E6963}")
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(guid.Data1).To(BeEquivalentTo(0x6a4b3c2d))
+			Expect(guid.Data2).To(BeEquivalentTo(0x1e0f))
+			Expect(guid.Data3).To(BeEquivalentTo(0x11e7))
+			Expect(guid.Data4).To(Equal([8]byte{0x8c, 0x3f, 0x80, 0x6e, 0x6f, 0x6e, 0x69, 0x63}))
+			Expect(guid.String()).To(Equal(systemVolume))
+		})
+
+		It("should parse without braces", func() {
+			guid, err := windows.ParseGUID("6a4b3c2d-1e0f-11e7-8c3f-806e6f6e6963")
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(guid.String()).To(Equal(systemVolume))
+		})
+
+		It("should reject a malformed GUID", func() {
+			_, err := windows.ParseGUID("{6a4b3c2d-1e0f-11e7-8c3f-806e6f6e696z}")
+
+			Expect(err).Should(Equal(windows.ErrInvalidGUID))
+		})
+	})
+
+	Context("when a volume GUID path is present", func() {
+		BeforeEach(func() {
+			subject = windows.Path("\\\\?\\Volume" + systemVolume + "\\Program Files\\x")
+
+			Expect(subject).ShouldNot(BeNil())
+		})
+
+		It("should not have errors present", func() {
+			Expect(subject.Errors()).To(BeEmpty())
+		})
+
+		It("should have the correct volume", func() {
+			Expect(subject.IsVolume()).To(BeTrue())
+			Expect(subject.Volume().String()).To(Equal(systemVolume))
+		})
+
+		It("should not have a disk/device present", func() {
+			Expect(subject.Device()).To(BeEmpty())
+		})
+
+		It("should have the correct paths present", func() {
+			Expect(subject.Dirs()).To(BeEquivalentTo([]string{"Program Files"}))
+			Expect(subject.Name()).To(Equal("x"))
+		})
+
+		It("should generate an UNICODE UNC path", func() {
+			Expect(subject.ToUnicodeUNC()).To(Equal("\\\\?\\Volume" + systemVolume + "\\Program Files\\x"))
+			Expect(subject.ToString()).To(Equal(subject.ToUnicodeUNC()))
+		})
+	})
+
+	DescribeTable("when a volume itself is present",
+		func(target string) {
+			subject = windows.Path(target)
+
+			Expect(subject.IsVolume()).To(BeTrue())
+			Expect(subject.ToUnicodeUNC()).To(Equal(target))
+		},
+		Entry("a volume device", "\\\\?\\Volume"+systemVolume),
+		Entry("a volume root directory", "\\\\?\\Volume"+systemVolume+"\\"),
+	)
+
+	Context("when a path is not a volume GUID path", func() {
+		It("should not have a volume", func() {
+			subject = windows.Path("\\\\?\\Volume{not-a-guid}\\x")
+
+			Expect(subject.IsVolume()).To(BeFalse())
+			Expect(subject.Dirs()).To(BeEquivalentTo([]string{"Volume{not-a-guid}"}))
+		})
+	})
+
+	Context("when translating volume GUID paths", func() {
+		var mounts windows.VolumeMounts
+
+		BeforeEach(func() {
+			system, _ := windows.ParseGUID(systemVolume)
+			data, _ := windows.ParseGUID(dataVolume)
+			mounts = windows.VolumeMounts{system: "C:\\", data: "C:\\mnt\\data"}
+		})
+
+		DescribeTable("to a mount point",
+			func(target string, expected string) {
+				mounted, err := windows.Path(target).MountedPath(mounts)
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(mounted.ToString()).To(Equal(expected))
+			},
+			Entry("a file on a drive", "\\\\?\\Volume"+systemVolume+"\\Program Files\\x", "C:\\Program Files\\x"),
+			Entry("a file on a directory", "\\\\?\\Volume"+dataVolume+"\\x", "C:\\mnt\\data\\x"),
+			Entry("a volume root", "\\\\?\\Volume"+systemVolume+"\\", "C:\\"),
+			Entry("a stream", "\\\\?\\Volume"+dataVolume+"\\x:s:$DATA", "C:\\mnt\\data\\x:s:$DATA"),
+		)
+
+		DescribeTable("from a mount point",
+			func(target string, expected string) {
+				volume, err := windows.Path(target).VolumePath(mounts)
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(volume.IsVolume()).To(BeTrue())
+				Expect(volume.ToUnicodeUNC()).To(Equal(expected))
+			},
+			Entry("a file on a drive", "C:\\Program Files\\x", "\\\\?\\Volume"+systemVolume+"\\Program Files\\x"),
+			Entry("a file on a directory", "c:\\MNT\\Data\\x", "\\\\?\\Volume"+dataVolume+"\\x"),
+			Entry("a directory", "C:\\Windows\\", "\\\\?\\Volume"+systemVolume+"\\Windows\\"),
+			Entry("a stream", "C:\\mnt\\data\\x:s", "\\\\?\\Volume"+dataVolume+"\\x:s"),
+		)
+
+		It("should fail for an unknown volume", func() {
+			_, err := windows.Path("\\\\?\\Volume{00000000-0000-0000-0000-000000000000}\\x").MountedPath(mounts)
+
+			Expect(err).Should(Equal(windows.ErrUnknownVolume))
+		})
+
+		It("should fail for a path on no mount point", func() {
+			_, err := windows.Path("D:\\x").VolumePath(mounts)
+
+			Expect(err).Should(Equal(windows.ErrUnknownVolume))
+		})
+	})
+})
@@ -0,0 +1,138 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package windows
+
+import "strings"
+
+// WithName returns a new Path, with the Name() replaced by the given file
+// or directory name; dropping any stream and errors of the previous name.
+// The name is validated just as Path() does. An empty name leaves the Path
+// referring to its directory.
+func (p *PathImpl) WithName(name string) *PathImpl {
+	named := p.clone()
+	named.replaceComponentErrors(len(p.dirs), len(p.dirs)+1, 0)
+	named.name = name
+	named.stream, named.streamType = "", ""
+	if len(name) > 0 {
+		named.validateComponent(name, len(named.dirs))
+	}
+	return named
+}
+
+// WithDirs returns a new Path, with the Dirs() replaced by the given
+// directory names, dropping the errors of the previous ones. Each name is
+// validated just as Path() does.
+func (p *PathImpl) WithDirs(dirs []string) *PathImpl {
+	moved := p.clone()
+	moved.replaceComponentErrors(0, len(p.dirs), len(dirs)-len(p.dirs))
+	moved.dirs = nil
+	for i, dir := range dirs {
+		moved.dirs = append(moved.dirs, dir)
+		moved.validateComponent(dir, i)
+	}
+	return moved
+}
+
+// WithDevice returns a new Path on the given drive; such as ``D'' or
+// ``D:''. A UNC, volume or device Path is rooted at the drive instead, while
+// a UNICODE or NT path keeps its namespace prefix. An empty device removes
+// the drive, leaving a root-relative or relative Path. An invalid drive
+// letter is recorded as an error.
+func (p *PathImpl) WithDevice(device string) *PathImpl {
+	moved := p.clone()
+	device = strings.TrimSuffix(device, ":")
+	if len(device) > 0 {
+		c, err := isDriveLetter(rune(device[0]))
+		if err != nil || len(device) > 1 {
+			moved.addError(ErrInvalidDrive, -1, -1, 0)
+			return moved
+		}
+		device = string(c)
+	}
+
+	if moved.unc || moved.volume != nil || moved.rootDevice || moved.isNTDevice() || (moved.devicePath && len(moved.device) == 0) {
+		moved.absolute = true
+	}
+	if moved.isNTDevice() {
+		moved.ntPrefix = ntDosDevices
+	}
+	moved.node, moved.share, moved.unc = "", "", false
+	moved.volume, moved.rootDevice = nil, false
+	moved.deviceName, moved.devicePath = "", false
+	moved.device = device
+	if p.devicePath && len(device) > 0 {
+		moved.setDeviceName(device + ":")
+		moved.devicePath = true
+	}
+	if len(device) == 0 && (moved.unicode || moved.devicePath) {
+		// a namespace prefix requires a drive
+		moved.unicode, moved.ntPrefix = false, ""
+	}
+	return moved
+}
+
+// Ext returns the extension of the Name(); that is, the text beginning at
+// its final dot. A Name() without a dot has no extension.
+func (p *PathImpl) Ext() string {
+	if i := strings.LastIndexByte(p.name, '.'); i >= 0 {
+		return p.name[i:]
+	}
+	return ""
+}
+
+// WithExt returns a new Path, with the extension of the Name() replaced by
+// the given extension; with or without its leading dot. An empty extension
+// removes it. A Path without a Name() is returned as-is. The new name is
+// validated just as Path() does, in place of the previous one.
+func (p *PathImpl) WithExt(ext string) *PathImpl {
+	if len(p.name) == 0 {
+		return p
+	}
+	if len(ext) > 0 && !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	named := p.clone()
+	named.replaceComponentErrors(len(p.dirs), len(p.dirs)+1, 0)
+	named.name = strings.TrimSuffix(p.name, p.Ext()) + ext
+	named.validateComponent(named.name, len(named.dirs))
+	return named
+}
+
+// Parent returns a new Path, referring to the directory containing the
+// Path; by purely lexical processing, so Clean() should be used first to
+// resolve any ``.'' or ``..'' components. The parent of a root is the root
+// itself, while the parent of a single relative component is ``.''.
+func (p *PathImpl) Parent() *PathImpl {
+	parent := p.clone()
+	parent.stream, parent.streamType = "", ""
+	if len(parent.name) == 0 && len(parent.dirs) > 0 {
+		parent.dirs = parent.dirs[:len(parent.dirs)-1]
+	}
+	parent.name = ""
+	if len(parent.dirs) > 0 {
+		parent.name = parent.dirs[len(parent.dirs)-1]
+		parent.dirs = parent.dirs[:len(parent.dirs)-1]
+		// the errors of the new Name() keep their component
+		parent.replaceComponentErrors(len(parent.dirs)+1, len(p.dirs)+1, 0)
+	} else {
+		parent.replaceComponentErrors(0, len(p.dirs)+1, 0)
+		if !parent.absolute && !parent.unc && !parent.unicode && !parent.devicePath && len(parent.device) == 0 {
+			parent.name = "."
+		}
+	}
+	return parent
+}
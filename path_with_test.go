@@ -0,0 +1,203 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package windows_test
+
+import (
+	"sync"
+
+	"gitlab.com/jbenden/windows"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("With", func() {
+	DescribeTable("when replacing the name",
+		func(target, name, expected string) {
+			Expect(windows.Path(target).WithName(name).ToString()).To(Equal(expected))
+		},
+		Entry("a file", "C:\\dir\\file.txt", "other.md", "C:\\dir\\other.md"),
+		Entry("a directory", "C:\\dir\\", "file", "C:\\dir\\file"),
+		Entry("a stream", "C:\\dir\\file:stream", "other", "C:\\dir\\other"),
+		Entry("an empty name", "C:\\dir\\file", "", "C:\\dir\\"),
+		Entry("a UNC share", "\\\\peaches\\msys64\\file", "other", "\\\\peaches\\msys64\\other"),
+	)
+
+	DescribeTable("when replacing the extension",
+		func(target, ext, expected string) {
+			Expect(windows.Path(target).WithExt(ext).ToString()).To(Equal(expected))
+		},
+		Entry("a file", "C:\\dir\\file.txt", ".md", "C:\\dir\\file.md"),
+		Entry("an extension without a dot", "C:\\dir\\file.txt", "md", "C:\\dir\\file.md"),
+		Entry("a file without an extension", "C:\\dir\\file", ".md", "C:\\dir\\file.md"),
+		Entry("a removed extension", "C:\\dir\\file.tar.gz", "", "C:\\dir\\file.tar"),
+		Entry("a stream", "C:\\dir\\file.txt:stream", ".md", "C:\\dir\\file.md:stream"),
+		Entry("a directory", "C:\\dir\\", ".md", "C:\\dir\\"),
+	)
+
+	DescribeTable("when replacing the directories",
+		func(target string, dirs []string, expected string) {
+			Expect(windows.Path(target).WithDirs(dirs).ToString()).To(Equal(expected))
+		},
+		Entry("a file", "C:\\dir\\file", []string{"a", "b"}, "C:\\a\\b\\file"),
+		Entry("no directories", "C:\\dir\\file", []string(nil), "C:\\file"),
+		Entry("a relative path", "dir\\file", []string{"a"}, "a\\file"),
+	)
+
+	DescribeTable("when replacing the drive",
+		func(target, device, expected string) {
+			subject := windows.Path(target).WithDevice(device)
+
+			Expect(subject.Errors()).To(BeEmpty())
+			Expect(subject.ToString()).To(Equal(expected))
+		},
+		Entry("an absolute path", "C:\\dir\\file", "D", "D:\\dir\\file"),
+		Entry("a drive with a colon", "C:\\dir\\file", "d:", "D:\\dir\\file"),
+		Entry("a drive-relative path", "C:dir\\file", "D", "D:dir\\file"),
+		Entry("a root-relative path", "\\dir\\file", "D", "D:\\dir\\file"),
+		Entry("a UNC path", "\\\\peaches\\msys64\\home", "D", "D:\\home"),
+		Entry("a UNICODE path", "\\\\?\\C:\\dir", "D", "\\\\?\\D:\\dir"),
+		Entry("a device namespace drive", "\\\\.\\C:\\dir", "D", "\\\\.\\D:\\dir"),
+		Entry("a removed drive", "C:\\dir\\file", "", "\\dir\\file"),
+		Entry("a removed UNICODE drive", "\\\\?\\C:\\dir", "", "\\dir"),
+	)
+
+	DescribeTable("when taking the parent",
+		func(target, expected string) {
+			Expect(windows.Path(target).Parent().ToString()).To(Equal(expected))
+		},
+		Entry("a file", "C:\\dir\\file", "C:\\dir"),
+		Entry("a directory", "C:\\dir\\sub\\", "C:\\dir"),
+		Entry("a file in the root", "C:\\file", "C:\\"),
+		Entry("the root", "C:\\", "C:\\"),
+		Entry("a drive-relative file", "C:file", "C:"),
+		Entry("a relative file", "file", "."),
+		Entry("a stream", "C:\\dir\\file:stream", "C:\\dir"),
+		Entry("a UNC path", "\\\\peaches\\msys64\\home", "\\\\peaches\\msys64"),
+		Entry("a UNICODE path", "\\\\?\\C:\\dir\\file", "\\\\?\\C:\\dir"),
+	)
+
+	Context("when the replacement is invalid", func() {
+		It("should record a reserved name", func() {
+			Expect(windows.Path("C:\\dir\\file").WithName("nul").Errors()).To(ContainElement(MatchError(windows.ErrReservedName)))
+		})
+
+		It("should record a reserved character in an extension", func() {
+			Expect(windows.Path("C:\\dir\\file").WithExt(".t*t").Errors()).To(ContainElement(MatchError(windows.ErrReservedChar)))
+		})
+
+		It("should record a reserved character in a directory", func() {
+			Expect(windows.Path("C:\\dir\\file").WithDirs([]string{"a", "b|c"}).Errors()).To(ContainElement(MatchError(windows.ErrReservedChar)))
+		})
+
+		It("should record a trailing dot in a name", func() {
+			Expect(windows.Path("C:\\a\\b").WithName("foo.").Errors()).To(ContainElement(MatchError(windows.ErrTrailingDotOrSpace)))
+		})
+
+		It("should record a trailing dot in a directory", func() {
+			Expect(windows.Path("C:\\a\\b").WithDirs([]string{"x."}).Errors()).To(ContainElement(MatchError(windows.ErrTrailingDotOrSpace)))
+		})
+
+		It("should record an invalid drive", func() {
+			Expect(windows.Path("C:\\dir\\file").WithDevice("1").Errors()).To(ContainElement(MatchError(windows.ErrInvalidDrive)))
+		})
+	})
+
+	Context("when the replaced part is invalid", func() {
+		It("should drop the errors of the previous name", func() {
+			subject := windows.Path("C:\\a\\b|c")
+			Expect(subject.Errors()).NotTo(BeEmpty())
+
+			Expect(subject.WithName("ok").Errors()).To(BeEmpty())
+		})
+
+		It("should drop the errors of the previous directories", func() {
+			subject := windows.Path("C:\\a|b\\c")
+			Expect(subject.Errors()).NotTo(BeEmpty())
+
+			Expect(subject.WithDirs([]string{"x", "y"}).Errors()).To(BeEmpty())
+		})
+
+		It("should keep the errors of the name, when replacing the directories", func() {
+			subject := windows.Path("C:\\a|b\\c|d").WithDirs([]string{"x", "y"})
+
+			Expect(subject.Errors()).To(HaveLen(1))
+			Expect(subject.Errors()[0].(*windows.PathError).Component).To(Equal(2))
+			Expect(subject.Errors()[0].(*windows.PathError).Rune).To(Equal('|'))
+		})
+
+		It("should drop the errors of the previous extension", func() {
+			Expect(windows.Path("C:\\a\\b.t|t").WithExt(".txt").Errors()).To(BeEmpty())
+		})
+
+		It("should validate the whole new name, when replacing the extension", func() {
+			Expect(windows.Path("C:\\a\\con.txt").WithExt("").Errors()).To(ContainElement(MatchError(windows.ErrReservedName)))
+			Expect(windows.Path("C:\\a\\b.txt").WithExt(".").Errors()).To(ContainElement(MatchError(windows.ErrTrailingDotOrSpace)))
+		})
+
+		It("should drop the errors of the name removed by Parent", func() {
+			Expect(windows.Path("C:\\a\\b|c").Parent().Errors()).To(BeEmpty())
+			Expect(windows.Path("C:\\a\\b|c\\").Parent().Errors()).To(BeEmpty())
+			Expect(windows.Path("b|c").Parent().Errors()).To(BeEmpty())
+		})
+
+		It("should keep the errors of the new name of Parent", func() {
+			subject := windows.Path("C:\\a|b\\c").Parent()
+
+			Expect(subject.Errors()).To(HaveLen(1))
+			Expect(subject.Errors()[0].(*windows.PathError).Component).To(Equal(0))
+		})
+
+		It("should keep the errors of the directories, when replacing the name", func() {
+			subject := windows.Path("C:\\a|b\\c|d").WithName("ok")
+
+			Expect(subject.Errors()).To(HaveLen(1))
+			Expect(subject.Errors()[0].(*windows.PathError).Component).To(Equal(0))
+		})
+	})
+
+	Context("when sharing a path", func() {
+		It("should never modify the original", func() {
+			subject := windows.Path("C:\\dir\\file.txt")
+
+			subject.MakeDirectory()
+			subject.WithName("other")
+			subject.WithExt(".md")
+			subject.WithDirs([]string{"a"})
+			subject.WithDevice("D")
+			subject.Parent()
+
+			Expect(subject.ToString()).To(Equal("C:\\dir\\file.txt"))
+		})
+
+		It("should be safe for concurrent readers", func() {
+			subject := windows.Path("C:\\dir\\file.txt")
+
+			var wg sync.WaitGroup
+			for i := 0; i < 8; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					subject.MakeDirectory().WithExt(".md").Parent().ToString()
+				}()
+			}
+			wg.Wait()
+
+			Expect(subject.Dirs()).To(BeEquivalentTo([]string{"dir"}))
+		})
+	})
+})
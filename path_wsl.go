@@ -0,0 +1,109 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package windows
+
+import "strings"
+
+// WSLConfig describes a WSL distribution, for translating paths with
+// ToWSL() and FromWSL().
+type WSLConfig struct {
+	// Root is the automount root of the Windows drives, as set in
+	// ``wsl.conf''; by default, ``/mnt/''.
+	Root string
+	// Distro is the name of the distribution; required for translating a
+	// path outside of the automount root into a Windows path.
+	Distro string
+	// Legacy selects the ``\\wsl$\'' prefix, used before Windows 10 21H2,
+	// rather than ``\\wsl.localhost\''.
+	Legacy bool
+}
+
+// root returns the automount root, ending with a slash.
+func (cfg *WSLConfig) root() string {
+	if cfg == nil || len(cfg.Root) == 0 {
+		return "/mnt/"
+	}
+	if !strings.HasSuffix(cfg.Root, "/") {
+		return cfg.Root + "/"
+	}
+	return cfg.Root
+}
+
+// isWSLShare determines if the Path is on the UNC share of a WSL
+// distribution; such as ``\\wsl$\Ubuntu\'' or ``\\wsl.localhost\Ubuntu\''.
+func (p *PathImpl) isWSLShare() bool {
+	return p.unc && len(p.share) > 0 && (strings.EqualFold(p.node, "wsl$") || strings.EqualFold(p.node, "wsl.localhost"))
+}
+
+// ToWSL translates the Path into the path seen within a WSL distribution,
+// just as ``wslpath -u'' does; such as ``/mnt/c/src/app'' for
+// ``C:\src\app'', or ``/home/joe'' for ``\\wsl$\Ubuntu\home\joe''. A
+// relative Path remains relative. The cfg may be nil.
+//
+// ErrNotRepresentable is returned for a Path which cannot be reached from
+// within the distribution; such as another UNC share, the share of another
+// distribution, a drive-relative or root-relative Path, a stream, or a
+// UNICODE name holding a forward slash.
+func (p *PathImpl) ToWSL(cfg *WSLConfig) (string, error) {
+	if (p.unicode && !p.isWin32Safe()) || p.hasSlash() || len(p.stream) > 0 || len(p.streamType) > 0 {
+		return "", ErrNotRepresentable
+	}
+
+	switch {
+	case p.isWSLShare():
+		if cfg != nil && len(cfg.Distro) > 0 && !strings.EqualFold(cfg.Distro, p.share) {
+			return "", ErrNotRepresentable
+		}
+		return "/" + p.joinPOSIX(decodePrivateUse), nil
+	case len(p.device) > 0 && p.absolute:
+		return cfg.root() + strings.ToLower(p.device) + "/" + p.joinPOSIX(decodePrivateUse), nil
+	case len(p.device) == 0 && !p.absolute && !p.unc && !p.devicePath:
+		return p.joinPOSIX(decodePrivateUse), nil
+	}
+	return "", ErrNotRepresentable
+}
+
+// FromWSL translates a path seen within a WSL distribution into a Path,
+// just as ``wslpath -w'' does; such as ``C:\src\app'' for
+// ``/mnt/c/src/app'', or ``\\wsl.localhost\Ubuntu\home\joe'' for
+// ``/home/joe''. A relative path remains relative. Characters which Windows
+// does not permit in names are mapped into the U+F000 private use range,
+// as WSL does. The cfg may be nil.
+//
+// ErrNotRepresentable is returned for a path outside of the automount
+// root, when the cfg does not name the Distro.
+func FromWSL(path string, cfg *WSLConfig) (*PathImpl, error) {
+	if !strings.HasPrefix(path, "/") {
+		comps, dir := splitPOSIX(path)
+		return fromPOSIX("", comps, dir, encodePrivateUse), nil
+	}
+
+	if drive, rest, ok := posixDrive(path, cfg.root()); ok {
+		comps, dir := splitPOSIX(rest)
+		return fromPOSIX(drive+":\\", comps, dir, encodePrivateUse), nil
+	}
+
+	if cfg == nil || len(cfg.Distro) == 0 {
+		return nil, ErrNotRepresentable
+	}
+	node := "wsl.localhost"
+	if cfg.Legacy {
+		node = "wsl$"
+	}
+	comps, dir := splitPOSIX(path)
+	return fromPOSIX("\\\\"+node+"\\"+cfg.Distro+"\\", comps, dir, encodePrivateUse), nil
+}
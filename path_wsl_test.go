@@ -0,0 +1,108 @@
+/*
+Copyright 2017 Joseph Benden <joe@benden.us>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package windows_test
+
+import (
+	"gitlab.com/jbenden/windows"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WSL", func() {
+	ubuntu := &windows.WSLConfig{Distro: "Ubuntu"}
+
+	DescribeTable("when translating to WSL",
+		func(target string, cfg *windows.WSLConfig, expected string) {
+			wsl, err := windows.Path(target).ToWSL(cfg)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(wsl).To(Equal(expected))
+		},
+		Entry("a drive path", "C:\\src\\app", nil, "/mnt/c/src/app"),
+		Entry("a drive root", "D:\\", nil, "/mnt/d/"),
+		Entry("a directory", "C:\\src\\", nil, "/mnt/c/src/"),
+		Entry("forward slashes", "C:/src/app", nil, "/mnt/c/src/app"),
+		Entry("an automount root", "C:\\src", &windows.WSLConfig{Root: "/"}, "/c/src"),
+		Entry("an automount root without a slash", "C:\\src", &windows.WSLConfig{Root: "/windir"}, "/windir/c/src"),
+		Entry("a UNICODE path", "\\\\?\\C:\\src", nil, "/mnt/c/src"),
+		Entry("a WSL share", "\\\\wsl$\\Ubuntu\\home\\joe", nil, "/home/joe"),
+		Entry("a WSL localhost share", "\\\\wsl.localhost\\Ubuntu\\home\\joe", ubuntu, "/home/joe"),
+		Entry("the root of a WSL share", "\\\\wsl$\\Ubuntu", nil, "/"),
+		Entry("a UNICODE WSL share", "\\\\?\\UNC\\wsl.localhost\\Ubuntu\\etc", nil, "/etc"),
+		Entry("a relative path", "src\\app", nil, "src/app"),
+		Entry("a parent directory", "..\\app", nil, "../app"),
+		Entry("a mapped character", "C:\\a\uf03ab", nil, "/mnt/c/a:b"),
+	)
+
+	DescribeTable("when a path is not representable in WSL",
+		func(target string) {
+			_, err := windows.Path(target).ToWSL(ubuntu)
+
+			Expect(err).To(MatchError(windows.ErrNotRepresentable))
+		},
+		Entry("a UNC share", "\\\\peaches\\msys64\\home"),
+		Entry("the share of another distribution", "\\\\wsl$\\Debian\\home"),
+		Entry("a drive-relative path", "C:src"),
+		Entry("a root-relative path", "\\src"),
+		Entry("a stream", "C:\\file:stream"),
+		Entry("a device path", "\\\\.\\COM1"),
+		Entry("a volume", "\\\\?\\Volume{6a4b3c2d-1e0f-11e7-8c3f-806e6f6e6963}\\src"),
+		Entry("a UNICODE name holding a slash", "\\\\?\\C:\\a/b"),
+		Entry("a UNICODE directory holding a slash", "\\\\?\\C:\\a/b\\c"),
+	)
+
+	DescribeTable("when translating from WSL",
+		func(target string, cfg *windows.WSLConfig, expected string) {
+			subject, err := windows.FromWSL(target, cfg)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(subject.Errors()).To(BeEmpty())
+			Expect(subject.ToString()).To(Equal(expected))
+		},
+		Entry("a drive path", "/mnt/c/src/app", nil, "C:\\src\\app"),
+		Entry("a drive root", "/mnt/d", nil, "D:\\"),
+		Entry("a directory", "/mnt/c/src/", nil, "C:\\src\\"),
+		Entry("repeated slashes", "/mnt/c//src///app", nil, "C:\\src\\app"),
+		Entry("an automount root", "/c/src", &windows.WSLConfig{Root: "/"}, "C:\\src"),
+		Entry("a distribution path", "/home/joe", ubuntu, "\\\\wsl.localhost\\Ubuntu\\home\\joe"),
+		Entry("a legacy distribution path", "/home/joe", &windows.WSLConfig{Distro: "Ubuntu", Legacy: true}, "\\\\wsl$\\Ubuntu\\home\\joe"),
+		Entry("the distribution root", "/", ubuntu, "\\\\wsl.localhost\\Ubuntu"),
+		Entry("the automount root", "/mnt/", ubuntu, "\\\\wsl.localhost\\Ubuntu\\mnt\\"),
+		Entry("a long directory name under the automount root", "/mnt/data", ubuntu, "\\\\wsl.localhost\\Ubuntu\\mnt\\data"),
+		Entry("a relative path", "src/app", nil, "src\\app"),
+		Entry("a character Windows does not permit", "/mnt/c/a:b?", nil, "C:\\a\uf03ab\uf03f"),
+		Entry("a backslash", "/home/a\\b", ubuntu, "\\\\wsl.localhost\\Ubuntu\\home\\a\uf05cb"),
+	)
+
+	Context("when the distribution is unknown", func() {
+		It("should not translate a distribution path", func() {
+			_, err := windows.FromWSL("/home/joe", nil)
+
+			Expect(err).To(MatchError(windows.ErrNotRepresentable))
+		})
+	})
+
+	Context("when round-tripping", func() {
+		It("should restore the original path", func() {
+			subject, err := windows.FromWSL("/mnt/c/a:b/c|d", nil)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(subject.ToWSL(nil)).To(Equal("/mnt/c/a:b/c|d"))
+		})
+	})
+})